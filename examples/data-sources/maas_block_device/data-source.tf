@@ -0,0 +1,8 @@
+data "maas_block_device" "sda" {
+  machine = "machine-01"
+  name    = "sda"
+}
+
+output "sda_partitions" {
+  value = data.maas_block_device.sda.partitions
+}
@@ -0,0 +1,9 @@
+data "maas_controllers" "all" {}
+
+output "controllers_healthy" {
+  value = data.maas_controllers.all.healthy
+}
+
+output "unhealthy_controllers" {
+  value = [for c in data.maas_controllers.all.controllers : c.hostname if !c.healthy]
+}
@@ -0,0 +1,5 @@
+data "maas_dns_domains" "all" {}
+
+output "authoritative_domains" {
+  value = [for domain in data.maas_dns_domains.all.domains : domain.name if domain.authoritative]
+}
@@ -0,0 +1,9 @@
+data "maas_dns_record" "www" {
+  type = "A/AAAA"
+  fqdn = "www.maas"
+}
+
+data "maas_dns_record" "mail" {
+  type = "MX"
+  fqdn = "maas"
+}
@@ -0,0 +1,5 @@
+data "maas_fabrics" "all" {}
+
+output "fabric_names" {
+  value = data.maas_fabrics.all.fabrics[*].name
+}
@@ -0,0 +1,8 @@
+data "maas_ip_ranges" "dynamic" {
+  subnet = "10.10.0.0/24"
+  type   = "dynamic"
+}
+
+output "dynamic_ranges" {
+  value = [for r in data.maas_ip_ranges.dynamic.ip_ranges : "${r.start_ip}-${r.end_ip}"]
+}
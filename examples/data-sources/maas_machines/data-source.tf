@@ -0,0 +1,12 @@
+data "maas_machines" "ready_compute" {
+  status        = ["Ready"]
+  tags          = ["compute"]
+  not_tags      = ["maintenance"]
+  zone          = "default"
+  min_cpu_count = 16
+  min_memory    = 65536
+}
+
+output "ready_compute_hostnames" {
+  value = data.maas_machines.ready_compute.machines[*].hostname
+}
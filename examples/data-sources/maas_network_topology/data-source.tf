@@ -0,0 +1,20 @@
+data "maas_network_topology" "current" {}
+
+output "subnets" {
+  value = flatten([
+    for fabric in data.maas_network_topology.current.fabrics : [
+      for vlan in fabric.vlans : [
+        for subnet in vlan.subnets : {
+          fabric = fabric.name
+          vid    = vlan.vid
+          cidr   = subnet.cidr
+          space  = subnet.space
+        }
+      ]
+    ]
+  ])
+}
+
+output "vid_of_subnet" {
+  value = data.maas_network_topology.current.vids_by_cidr["10.10.0.0/24"]
+}
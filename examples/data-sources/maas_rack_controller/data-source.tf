@@ -1,3 +1,7 @@
 data "maas_rack_controller" "test_rack_controller" {
   hostname = "maas-rack-0"
 }
+
+output "rack_boot_images_status" {
+  value = data.maas_rack_controller.test_rack_controller.boot_images_status
+}
@@ -0,0 +1,3 @@
+data "maas_region_controller" "test_region_controller" {
+  hostname = "maas-region-0"
+}
@@ -0,0 +1,7 @@
+data "maas_space" "storage" {
+  name = "storage"
+}
+
+output "storage_subnets" {
+  value = data.maas_space.storage.subnets
+}
@@ -0,0 +1,5 @@
+data "maas_spaces" "all" {}
+
+output "space_subnets" {
+  value = { for space in data.maas_spaces.all.spaces : space.name => space.subnets }
+}
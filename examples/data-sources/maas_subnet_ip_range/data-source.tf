@@ -0,0 +1,4 @@
+data "maas_subnet_ip_range" "dynamic" {
+  start_ip = "10.88.88.100"
+  end_ip   = "10.88.88.200"
+}
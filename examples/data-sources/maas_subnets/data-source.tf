@@ -0,0 +1,8 @@
+data "maas_subnets" "internal" {
+  space       = "internal"
+  within_cidr = "10.0.0.0/8"
+}
+
+output "internal_cidrs" {
+  value = data.maas_subnets.internal.subnets[*].cidr
+}
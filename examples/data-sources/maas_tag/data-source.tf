@@ -0,0 +1,7 @@
+data "maas_tag" "virtual" {
+  name = "virtual"
+}
+
+output "virtual_machines" {
+  value = data.maas_tag.virtual.machines
+}
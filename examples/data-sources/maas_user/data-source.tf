@@ -0,0 +1,3 @@
+data "maas_user" "admin" {
+  name = "admin"
+}
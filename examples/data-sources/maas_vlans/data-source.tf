@@ -0,0 +1,7 @@
+data "maas_vlans" "default" {
+  fabric = "fabric-0"
+}
+
+output "dhcp_vids" {
+  value = [for vlan in data.maas_vlans.default.vlans : vlan.vid if vlan.dhcp_on]
+}
@@ -0,0 +1,7 @@
+data "maas_vm_cluster" "lxd" {
+  name = "cluster-01"
+}
+
+output "lxd_cluster_available_memory" {
+  value = { for h in data.maas_vm_cluster.lxd.hosts : h.name => h.resources_memory_available }
+}
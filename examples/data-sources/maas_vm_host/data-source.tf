@@ -0,0 +1,7 @@
+data "maas_vm_host" "kvm" {
+  name = "kvm-host-01"
+}
+
+output "kvm_storage_pools" {
+  value = { for p in data.maas_vm_host.kvm.storage_pools : p.name => p.total - p.used }
+}
@@ -0,0 +1,3 @@
+data "maas_vm_host_machine" "vm" {
+  machine = "vm-01"
+}
@@ -0,0 +1,7 @@
+data "maas_vm_hosts" "lxd" {
+  type = "lxd"
+}
+
+output "vm_hosts_available_cores" {
+  value = { for h in data.maas_vm_hosts.lxd.vm_hosts : h.name => h.resources_cores_available }
+}
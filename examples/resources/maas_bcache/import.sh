@@ -0,0 +1,2 @@
+# Bcaches can be imported with the machine identifier (system ID, hostname, or FQDN) and the bcache identifier (ID or name). e.g.
+$ terraform import maas_bcache.bcache0 storage01:bcache0
@@ -0,0 +1,14 @@
+resource "maas_bcache_cache_set" "nvme" {
+  machine      = maas_machine.storage01.id
+  cache_device = "nvme0n1"
+}
+
+resource "maas_bcache" "bcache0" {
+  machine        = maas_machine.storage01.id
+  name           = "bcache0"
+  backing_device = "sdb"
+  cache_set      = maas_bcache_cache_set.nvme.id
+  cache_mode     = "writeback"
+  fs_type        = "xfs"
+  mount_point    = "/srv"
+}
@@ -0,0 +1,4 @@
+resource "maas_bcache_cache_set" "nvme" {
+  machine      = maas_machine.storage01.id
+  cache_device = "nvme0n1"
+}
@@ -13,4 +13,7 @@ resource "maas_instance" "kvm" {
   deploy_params {
     distro_series = "focal"
   }
+  owner_data = {
+    owner = "virtualization-team"
+  }
 }
@@ -0,0 +1,2 @@
+# Logical volumes can be imported with the machine identifier (system ID, hostname, or FQDN) and the logical volume block device identifier (ID or name). e.g.
+$ terraform import maas_logical_volume.var machine-06:vg0-var
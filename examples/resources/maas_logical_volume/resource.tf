@@ -0,0 +1,17 @@
+resource "maas_logical_volume" "var" {
+  machine        = maas_machine.virsh_vm2.id
+  volume_group   = maas_volume_group.vg0.id
+  name           = "var"
+  size_gigabytes = 20
+  fs_type        = "ext4"
+  mount_point    = "/var"
+}
+
+resource "maas_logical_volume" "srv" {
+  machine        = maas_machine.virsh_vm2.id
+  volume_group   = maas_volume_group.vg0.id
+  name           = "srv"
+  size_gigabytes = 50
+  fs_type        = "xfs"
+  mount_point    = "/srv"
+}
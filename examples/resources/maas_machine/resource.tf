@@ -6,3 +6,30 @@ resource "maas_machine" "virsh_vm1" {
   })
   pxe_mac_address = "52:54:00:89:f5:3e"
 }
+
+resource "maas_machine" "server1" {
+  ipmi {
+    power_address   = "10.10.0.21"
+    power_user      = "maas"
+    power_pass      = "ipmi-password"
+    cipher_suite_id = "17"
+  }
+  pxe_mac_address     = "0c:c4:7a:6e:35:d1"
+  deletion_protection = true
+
+  release_on_destroy {
+    erase       = true
+    quick_erase = true
+  }
+}
+
+resource "maas_machine" "server2" {
+  power_type = "redfish"
+  power_parameters_wo = jsonencode({
+    power_address = "10.10.0.22"
+    power_user    = "maas"
+    power_pass    = "redfish-password"
+  })
+  power_parameters_wo_version = 1
+  pxe_mac_address             = "0c:c4:7a:6e:35:d2"
+}
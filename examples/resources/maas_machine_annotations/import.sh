@@ -0,0 +1,2 @@
+# Machine annotations can be imported using one of the machine attributes: system ID, hostname, or FQDN. e.g.
+$ terraform import maas_machine_annotations.db01 db01.maas
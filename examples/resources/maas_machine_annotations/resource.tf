@@ -0,0 +1,7 @@
+resource "maas_machine_annotations" "db01" {
+  machine = "db01.maas"
+  annotations = {
+    owner       = "dba-team"
+    cost_center = "cc-1234"
+  }
+}
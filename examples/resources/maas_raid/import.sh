@@ -0,0 +1,2 @@
+# RAID arrays can be imported with the machine identifier (system ID, hostname, or FQDN) and the RAID identifier (ID or name). e.g.
+$ terraform import maas_raid.md0 db01:md0
@@ -0,0 +1,16 @@
+resource "maas_raid" "md0" {
+  machine = maas_machine.db01.id
+  name    = "md0"
+  level   = "raid-10"
+  block_devices = [
+    "nvme0n1",
+    "nvme1n1",
+    "nvme2n1",
+    "nvme3n1",
+  ]
+  spare_devices = [
+    "nvme4n1",
+  ]
+  fs_type     = "xfs"
+  mount_point = "/var/lib/postgresql"
+}
@@ -4,3 +4,14 @@ resource "maas_user" "cloudbase" {
   email    = "admin@cloudbase.local"
   is_admin = true
 }
+
+ephemeral "random_password" "operator" {
+  length = 16
+}
+
+resource "maas_user" "operator" {
+  name                = "operator"
+  password_wo         = ephemeral.random_password.operator.result
+  password_wo_version = 1
+  email               = "operator@cloudbase.local"
+}
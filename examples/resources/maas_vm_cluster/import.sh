@@ -0,0 +1,2 @@
+# VM clusters can be imported using the ID or the name. e.g.
+$ terraform import maas_vm_cluster.lxd cluster-01
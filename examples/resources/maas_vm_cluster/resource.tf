@@ -0,0 +1,13 @@
+resource "maas_vm_cluster" "lxd" {
+  power_address       = "https://10.113.1.30:8443"
+  project             = "maas"
+  password_wo         = "lxd-trust-password"
+  password_wo_version = 1
+  zone                = "rack-01"
+}
+
+resource "maas_vm_host_machine" "lxd" {
+  vm_cluster = maas_vm_cluster.lxd.id
+  cores      = 2
+  memory     = 4096
+}
@@ -7,3 +7,12 @@ resource "maas_vm_host" "kvm" {
     "kvm",
   ]
 }
+
+resource "maas_vm_host" "lxd" {
+  type                 = "lxd"
+  power_address        = "https://10.113.1.25:8443"
+  project              = "maas"
+  password_wo          = "lxd-trust-password"
+  password_wo_version  = 1
+  generate_certificate = true
+}
@@ -0,0 +1,2 @@
+# Volume groups can be imported with the machine identifier (system ID, hostname, or FQDN) and the volume group identifier (ID or name). e.g.
+$ terraform import maas_volume_group.vg0 machine-06:vg0
@@ -0,0 +1,7 @@
+resource "maas_volume_group" "vg0" {
+  machine = maas_machine.virsh_vm2.id
+  name    = "vg0"
+  block_devices = [
+    maas_block_device.vdb.name,
+  ]
+}
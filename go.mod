@@ -5,6 +5,7 @@ go 1.22.7
 require (
 	github.com/bflad/tfproviderlint v0.31.0
 	github.com/canonical/gomaasclient v0.8.0
+	github.com/google/go-querystring v1.1.0
 	github.com/hashicorp/go-cty v1.4.1
 	github.com/hashicorp/go-set/v2 v2.1.0
 	github.com/hashicorp/terraform-plugin-docs v0.21.0
@@ -31,7 +32,6 @@ require (
 	github.com/fatih/color v1.16.0 // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/google/go-cmp v0.7.0 // indirect
-	github.com/google/go-querystring v1.1.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/hashicorp/cli v1.1.7 // indirect
 	github.com/hashicorp/errwrap v1.1.0 // indirect
@@ -0,0 +1,166 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasBlockDevice() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS machine's block device.",
+		ReadContext: dataSourceBlockDeviceRead,
+
+		Schema: map[string]*schema.Schema{
+			"block_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The block size of the block device.",
+			},
+			"id_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The block device ID path.",
+			},
+			"is_boot_device": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if the block device is set as the boot device.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The machine identifier (system ID, hostname, or FQDN) that owns the block device.",
+			},
+			"model": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Model of the block device.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The block device identifier (name, ID, ID path or path).",
+			},
+			"partitions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of partitions of the block device.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bootable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Boolean value indicating if the partition is set as bootable.",
+						},
+						"fs_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The file system type (e.g. `ext4`). This is empty if the partition is unformatted.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The label assigned if the partition is formatted.",
+						},
+						"mount_options": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The options used for the partition mount.",
+						},
+						"mount_point": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The mount point used. This is empty if the partition is not mounted.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the partition.",
+						},
+						"size_gigabytes": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The partition size (given in GB).",
+						},
+						"tags": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The tags assigned to the partition.",
+						},
+					},
+				},
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Block device path.",
+			},
+			"serial": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Serial number of the block device.",
+			},
+			"size_gigabytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the block device (given in GB).",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "A set of tag names assigned to the block device.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The block device type (e.g. `physical`, `virtual`).",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Block device UUID.",
+			},
+		},
+	}
+}
+
+func dataSourceBlockDeviceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockDevice, err := getBlockDevice(client, machine.SystemID, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := map[string]interface{}{
+		"id":             fmt.Sprintf("%v", blockDevice.ID),
+		"size_gigabytes": int(blockDevice.Size / (1024 * 1024 * 1024)),
+		"block_size":     blockDevice.BlockSize,
+		"is_boot_device": machine.BootDisk.ID == blockDevice.ID,
+		"partitions":     getBlockDevicePartitionsTFState(blockDevice),
+		"model":          blockDevice.Model,
+		"serial":         blockDevice.Serial,
+		"id_path":        blockDevice.IDPath,
+		"tags":           blockDevice.Tags,
+		"type":           blockDevice.Type,
+		"uuid":           blockDevice.UUID,
+		"path":           blockDevice.Path,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
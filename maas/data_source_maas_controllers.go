@@ -0,0 +1,381 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"sort"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// unhealthyControllerServiceStatuses are the MAAS service statuses that make a controller unhealthy.
+var unhealthyControllerServiceStatuses = []string{"dead", "degraded"}
+
+// rackControllerBootImages is the response of the rack controller list_boot_images operation,
+// which is not supported by gomaasclient.
+type rackControllerBootImages struct {
+	Status    string `json:"status"`
+	Connected bool   `json:"connected"`
+	Images    []struct {
+		Name         string   `json:"name"`
+		Architecture string   `json:"architecture"`
+		Subarches    []string `json:"subarches"`
+	} `json:"images"`
+}
+
+func dataSourceMaasControllers() *schema.Resource {
+	controllerSchema := getControllerTFSchema()
+	controllerSchema["hostname"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The hostname of the controller.",
+	}
+	controllerSchema["boot_images_status"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The boot images synchronization status of the controller (e.g. `synced`, `syncing`, `out-of-sync`). This is empty for the controllers that aren't rack controllers.",
+	}
+	controllerSchema["healthy"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Boolean value indicating if none of the controller services is `dead` or `degraded`.",
+	}
+
+	return &schema.Resource{
+		Description: "Provides details about all the MAAS region and rack controllers.",
+		ReadContext: dataSourceControllersRead,
+
+		Schema: map[string]*schema.Schema{
+			"controllers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of controllers, sorted by hostname. The controllers that are both region and rack controllers are only listed once.",
+				Elem: &schema.Resource{
+					Schema: controllerSchema,
+				},
+			},
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if all the controllers are healthy.",
+			},
+			"system_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of system IDs of the controllers, sorted by hostname.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceControllersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	rackControllers, err := client.RackControllers.Get(&entity.RackControllersGetParams{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	regionControllers, err := getRegionControllers(client, url.Values{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	fabrics, err := client.Fabrics.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rackSystemIDs := make([]string, len(rackControllers))
+	for i, rackController := range rackControllers {
+		rackSystemIDs[i] = rackController.SystemID
+	}
+
+	controllers := mergeControllers(rackControllers, regionControllers)
+	healthy := true
+	systemIDs := make([]string, len(controllers))
+	controllersState := make([]map[string]interface{}, len(controllers))
+	for i, controller := range controllers {
+		systemIDs[i] = controller.SystemID
+		controllerState := getControllerTFState(&controller, fabrics)
+		controllerState["hostname"] = controller.Hostname
+		controllerState["healthy"] = isControllerHealthy(controller.ServiceSet)
+		controllerState["boot_images_status"] = ""
+		if slices.Contains(rackSystemIDs, controller.SystemID) {
+			bootImages, err := getRackControllerBootImages(client, controller.SystemID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			controllerState["boot_images_status"] = bootImages.Status
+		}
+		healthy = healthy && controllerState["healthy"].(bool)
+		controllersState[i] = controllerState
+	}
+
+	d.SetId(getListID(systemIDs))
+	tfState := map[string]interface{}{
+		"controllers": controllersState,
+		"healthy":     healthy,
+		"system_ids":  systemIDs,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getControllerTFSchema returns the schema of the attributes shared by the controller data sources.
+func getControllerTFSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The description of the controller.",
+		},
+		"fqdn": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The FQDN of the controller.",
+		},
+		"interfaces": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The network interfaces of the controller.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Boolean value indicating if the network interface is enabled.",
+					},
+					"fabric": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The fabric of the network interface VLAN.",
+					},
+					"id": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The network interface ID.",
+					},
+					"ip_addresses": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The IP addresses configured on the network interface.",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"link_connected": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Boolean value indicating if the network interface link is connected.",
+					},
+					"mac_address": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The MAC address of the network interface.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The network interface name.",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The network interface type (e.g. `physical`, `bond`).",
+					},
+					"vid": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The traffic segregation ID of the network interface VLAN.",
+					},
+					"vlan_id": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The ID of the network interface VLAN.",
+					},
+				},
+			},
+		},
+		"ip_addresses": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "A list of IP addresses assigned to the controller.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"node_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The controller node type (e.g. `Rack controller`, `Region and rack controller`).",
+		},
+		"served_vlan_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The list of IDs of the VLANs served by the controller, i.e. the VLANs it's the primary or secondary rack controller of.",
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"services": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "The services running on the controller.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the service.",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The status of the service.",
+					},
+					"status_info": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Additional information about the status of the service.",
+					},
+				},
+			},
+		},
+		"system_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The system ID of the controller.",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The MAAS version of the controller.",
+		},
+	}
+}
+
+// getControllerTFState returns the state of the attributes shared by the controller data sources.
+func getControllerTFState(controller *entity.RackController, fabrics []entity.Fabric) map[string]interface{} {
+	ipAddresses := make([]string, len(controller.IPAddresses))
+	for i, ip := range controller.IPAddresses {
+		ipAddresses[i] = ip.String()
+	}
+	services := make([]map[string]interface{}, len(controller.ServiceSet))
+	for i, service := range controller.ServiceSet {
+		services[i] = map[string]interface{}{
+			"name":        service.Name,
+			"status":      service.Status,
+			"status_info": service.StatusInfo,
+		}
+	}
+	interfaces := make([]map[string]interface{}, len(controller.InterfaceSet))
+	for i, networkInterface := range controller.InterfaceSet {
+		interfaceIPAddresses := []string{}
+		for _, link := range networkInterface.Links {
+			if link.IPAddress != "" {
+				interfaceIPAddresses = append(interfaceIPAddresses, link.IPAddress)
+			}
+		}
+		interfaces[i] = map[string]interface{}{
+			"id":             networkInterface.ID,
+			"name":           networkInterface.Name,
+			"type":           networkInterface.Type,
+			"mac_address":    networkInterface.MACAddress,
+			"enabled":        networkInterface.Enabled,
+			"link_connected": networkInterface.LinkConnected,
+			"fabric":         networkInterface.VLAN.Fabric,
+			"vlan_id":        networkInterface.VLAN.ID,
+			"vid":            networkInterface.VLAN.VID,
+			"ip_addresses":   interfaceIPAddresses,
+		}
+	}
+	return map[string]interface{}{
+		"system_id":       controller.SystemID,
+		"fqdn":            controller.FQDN,
+		"description":     controller.Description,
+		"version":         controller.Version,
+		"node_type":       controller.NodeTypeName,
+		"ip_addresses":    ipAddresses,
+		"services":        services,
+		"interfaces":      interfaces,
+		"served_vlan_ids": getControllerServedVlanIDs(controller.SystemID, fabrics),
+	}
+}
+
+// getControllerServedVlanIDs returns the sorted IDs of the VLANs the controller is the primary or secondary rack controller of.
+func getControllerServedVlanIDs(systemID string, fabrics []entity.Fabric) []int {
+	vlanIDs := []int{}
+	for _, fabric := range fabrics {
+		for _, vlan := range fabric.VLANs {
+			if vlan.PrimaryRack == systemID || vlan.SecondaryRack == systemID {
+				vlanIDs = append(vlanIDs, vlan.ID)
+			}
+		}
+	}
+	sort.Ints(vlanIDs)
+	return vlanIDs
+}
+
+// getRegionControllers returns the MAAS region controllers. The region controllers endpoint
+// is not supported by gomaasclient, so the MAAS API is called directly. Region controllers
+// are returned with the same fields as rack controllers.
+func getRegionControllers(client *client.Client, params url.Values) ([]entity.RackController, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	regionControllers := []entity.RackController{}
+	err = apiClient.GetSubObject("regioncontrollers").Get("", params, func(data []byte) error {
+		return json.Unmarshal(data, &regionControllers)
+	})
+	return regionControllers, err
+}
+
+// getRackControllerBootImages returns the boot images of a rack controller and their synchronization status.
+func getRackControllerBootImages(client *client.Client, systemID string) (*rackControllerBootImages, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	bootImages := new(rackControllerBootImages)
+	err = apiClient.GetSubObject("rackcontrollers").GetSubObject(systemID).Get("list_boot_images", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, bootImages)
+	})
+	return bootImages, err
+}
+
+// mergeControllers returns the rack and region controllers sorted by hostname.
+// The controllers that are both region and rack controllers are only returned once.
+func mergeControllers(rackControllers []entity.RackController, regionControllers []entity.RackController) []entity.RackController {
+	result := []entity.RackController{}
+	systemIDs := []string{}
+	for _, controller := range slices.Concat(rackControllers, regionControllers) {
+		if slices.Contains(systemIDs, controller.SystemID) {
+			continue
+		}
+		systemIDs = append(systemIDs, controller.SystemID)
+		result = append(result, controller)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Hostname < result[j].Hostname
+	})
+	return result
+}
+
+func isControllerHealthy(services []entity.MachineServiceSet) bool {
+	for _, service := range services {
+		if slices.Contains(unhealthyControllerServiceStatuses, service.Status) {
+			return false
+		}
+	}
+	return true
+}
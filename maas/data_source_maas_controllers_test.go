@@ -0,0 +1,109 @@
+package maas
+
+import (
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeControllers(t *testing.T) {
+	rackControllers := []entity.RackController{
+		{SystemID: "abc123", Hostname: "rack-2", NodeTypeName: "Rack controller"},
+		{SystemID: "def456", Hostname: "region-rack", NodeTypeName: "Region and rack controller"},
+	}
+	regionControllers := []entity.RackController{
+		{SystemID: "def456", Hostname: "region-rack", NodeTypeName: "Region and rack controller"},
+		{SystemID: "ghi789", Hostname: "region-1", NodeTypeName: "Region controller"},
+	}
+
+	controllers := mergeControllers(rackControllers, regionControllers)
+	systemIDs := make([]string, len(controllers))
+	for i, controller := range controllers {
+		systemIDs[i] = controller.SystemID
+	}
+	assert.Equal(t, []string{"abc123", "ghi789", "def456"}, systemIDs)
+}
+
+func TestIsControllerHealthy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		services []entity.MachineServiceSet
+		out      bool
+	}{
+		{
+			name: "no services",
+			out:  true,
+		},
+		{
+			name: "running and off services",
+			services: []entity.MachineServiceSet{
+				{Name: "rackd", Status: "running"},
+				{Name: "dhcpd6", Status: "off"},
+			},
+			out: true,
+		},
+		{
+			name: "degraded service",
+			services: []entity.MachineServiceSet{
+				{Name: "rackd", Status: "running"},
+				{Name: "regiond", Status: "degraded", StatusInfo: "1 process running but 4 were expected."},
+			},
+			out: false,
+		},
+		{
+			name: "dead service",
+			services: []entity.MachineServiceSet{
+				{Name: "bind9", Status: "dead"},
+			},
+			out: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, isControllerHealthy(testCase.services))
+		})
+	}
+}
+
+func TestGetControllerServedVlanIDs(t *testing.T) {
+	fabrics := []entity.Fabric{
+		{ID: 1, VLANs: []entity.VLAN{
+			{ID: 5001, PrimaryRack: "abc123"},
+			{ID: 5002, PrimaryRack: "def456", SecondaryRack: "abc123"},
+		}},
+		{ID: 2, VLANs: []entity.VLAN{
+			{ID: 5003},
+			{ID: 5004, PrimaryRack: "def456"},
+		}},
+	}
+
+	testCases := []struct {
+		name     string
+		systemID string
+		out      []int
+	}{
+		{
+			name:     "primary and secondary rack",
+			systemID: "abc123",
+			out:      []int{5001, 5002},
+		},
+		{
+			name:     "primary rack on several fabrics",
+			systemID: "def456",
+			out:      []int{5002, 5004},
+		},
+		{
+			name:     "region controller",
+			systemID: "ghi789",
+			out:      []int{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, getControllerServedVlanIDs(testCase.systemID, fabrics))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasDnsDomain() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS DNS domain.",
+		ReadContext: dataSourceDnsDomainRead,
+
+		Schema: map[string]*schema.Schema{
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if the domain is authoritative.",
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if the domain is the default one.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The domain identifier (name or ID).",
+			},
+			"resource_record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of DNS resource records in the domain.",
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The default TTL for the domain.",
+			},
+		},
+	}
+}
+
+func dataSourceDnsDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	domain, err := getDomain(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := getDnsDomainTFState(domain)
+	tfState["id"] = fmt.Sprintf("%v", domain.ID)
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasDnsDomains() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about all the MAAS DNS domains.",
+		ReadContext: dataSourceDnsDomainsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of DNS domains.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"authoritative": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Boolean value indicating if the domain is authoritative.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The domain ID.",
+						},
+						"is_default": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Boolean value indicating if the domain is the default one.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The domain name.",
+						},
+						"resource_record_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of DNS resource records in the domain.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The default TTL for the domain.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDnsDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	domains, err := client.Domains.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := make([]string, len(domains))
+	domainsState := make([]map[string]interface{}, len(domains))
+	for i, domain := range domains {
+		ids[i] = fmt.Sprintf("%v", domain.ID)
+		domainsState[i] = getDnsDomainTFState(&domain)
+	}
+	d.SetId(getListID(ids))
+	if err := d.Set("domains", domainsState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getDnsDomainTFState(domain *entity.Domain) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                    domain.ID,
+		"name":                  domain.Name,
+		"ttl":                   domain.TTL,
+		"authoritative":         domain.Authoritative,
+		"is_default":            domain.IsDefault,
+		"resource_record_count": domain.ResourceRecordCount,
+	}
+}
@@ -0,0 +1,101 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMaasDnsRecord() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS DNS domain record.",
+		ReadContext: dataSourceDnsRecordRead,
+
+		Schema: map[string]*schema.Schema{
+			"data": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The data of the DNS record. For `A/AAAA` records, this is the space-separated list of IP addresses.",
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The DNS record identifier (fully qualified domain name or ID).",
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The TTL of the DNS record.",
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validDnsRecordTypes, false)),
+				Description:      "The DNS record type. Valid options are: `A/AAAA`, `CNAME`, `MX`, `NS`, `SRV`, `SSHFP`, `TXT`.",
+			},
+		},
+	}
+}
+
+func dataSourceDnsRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	identifier := d.Get("fqdn").(string)
+	var tfState map[string]interface{}
+	if d.Get("type").(string) == "A/AAAA" {
+		dnsRecord, err := getDnsResource(client, identifier)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ips := []string{}
+		for _, ipAddress := range dnsRecord.IPAddresses {
+			ips = append(ips, ipAddress.IP.String())
+		}
+		tfState = map[string]interface{}{
+			"id":   fmt.Sprintf("%v", dnsRecord.ID),
+			"data": strings.Join(ips, " "),
+			"fqdn": dnsRecord.FQDN,
+			"ttl":  dnsRecord.AddressTTL,
+		}
+	} else {
+		dnsRecord, err := getDnsResourceRecordByType(client, d.Get("type").(string), identifier)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tfState = map[string]interface{}{
+			"id":   fmt.Sprintf("%v", dnsRecord.ID),
+			"data": dnsRecord.RRData,
+			"fqdn": dnsRecord.FQDN,
+			"ttl":  dnsRecord.TTL,
+		}
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getDnsResourceRecordByType is like getDnsResourceRecord, but only matches the records of the given type,
+// since the same FQDN can have several records of different types.
+func getDnsResourceRecordByType(client *client.Client, rrType string, identifier string) (*entity.DNSResourceRecord, error) {
+	dnsResourceRecords, err := client.DNSResourceRecords.Get(&entity.DNSResourceRecordsParams{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dnsResourceRecords {
+		if !strings.EqualFold(d.RRType, rrType) {
+			continue
+		}
+		if fmt.Sprintf("%v", d.ID) == identifier || d.FQDN == identifier {
+			return &d, nil
+		}
+	}
+	return nil, fmt.Errorf("DNS resource record (%s %s) was not found", rrType, identifier)
+}
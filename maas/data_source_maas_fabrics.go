@@ -0,0 +1,86 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasFabrics() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about all the MAAS network fabrics.",
+		ReadContext: dataSourceFabricsRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabrics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of fabrics.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"class_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fabric class type.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The fabric ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fabric name.",
+						},
+						"vids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The list of traffic segregation IDs of the VLANs on the fabric.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFabricsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	fabrics, err := client.Fabrics.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := make([]string, len(fabrics))
+	fabricsState := make([]map[string]interface{}, len(fabrics))
+	for i, fabric := range fabrics {
+		ids[i] = fmt.Sprintf("%v", fabric.ID)
+		fabricsState[i] = getFabricTFState(&fabric)
+	}
+	d.SetId(getListID(ids))
+	if err := d.Set("fabrics", fabricsState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getFabricTFState(fabric *entity.Fabric) map[string]interface{} {
+	vids := make([]int, len(fabric.VLANs))
+	for i, vlan := range fabric.VLANs {
+		vids[i] = vlan.VID
+	}
+	return map[string]interface{}{
+		"id":         fabric.ID,
+		"name":       fabric.Name,
+		"class_type": fabric.ClassType,
+		"vids":       vids,
+	}
+}
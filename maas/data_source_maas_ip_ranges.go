@@ -0,0 +1,136 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMaasIPRanges() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about the MAAS IP ranges matching the given filters.",
+		ReadContext: dataSourceIPRangesRead,
+
+		Schema: map[string]*schema.Schema{
+			"ip_ranges": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of IP ranges matching the filters.",
+				Elem: &schema.Resource{
+					Schema: getIPRangeTFSchema(),
+				},
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the IP ranges of this subnet (ID or CIDR).",
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"dynamic", "reserved"}, false)),
+				Description:      "Only return the IP ranges of this type. Supported values are: `dynamic`, `reserved`.",
+			},
+		},
+	}
+}
+
+func dataSourceIPRangesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	ipRanges, err := client.IPRanges.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	subnetID := -1
+	if v, ok := d.GetOk("subnet"); ok {
+		subnet, err := getSubnet(client, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		subnetID = subnet.ID
+	}
+	rangeType := d.Get("type").(string)
+
+	ids := []string{}
+	ipRangesState := []map[string]interface{}{}
+	for _, ipRange := range ipRanges {
+		if subnetID >= 0 && ipRange.Subnet.ID != subnetID {
+			continue
+		}
+		if rangeType != "" && ipRange.Type != rangeType {
+			continue
+		}
+		ids = append(ids, fmt.Sprintf("%v", ipRange.ID))
+		ipRangesState = append(ipRangesState, getIPRangeTFState(&ipRange))
+	}
+	d.SetId(getListID(ids))
+	if err := d.Set("ip_ranges", ipRangesState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getIPRangeTFSchema returns the schema of an IP range returned by the network data sources.
+func getIPRangeTFSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"comment": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A description of the IP range.",
+		},
+		"end_ip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The end IP address of the range.",
+		},
+		"id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The IP range ID.",
+		},
+		"start_ip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The start IP address of the range.",
+		},
+		"subnet": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The CIDR of the IP range subnet.",
+		},
+		"subnet_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The ID of the IP range subnet.",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The IP range type (`dynamic` or `reserved`).",
+		},
+		"user": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The user who reserved the IP range.",
+		},
+	}
+}
+
+func getIPRangeTFState(ipRange *entity.IPRange) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        ipRange.ID,
+		"type":      ipRange.Type,
+		"start_ip":  ipRange.StartIP.String(),
+		"end_ip":    ipRange.EndIP.String(),
+		"comment":   ipRange.Comment,
+		"subnet":    ipRange.Subnet.CIDR,
+		"subnet_id": ipRange.Subnet.ID,
+		"user":      ipRange.User.UserName,
+	}
+}
@@ -0,0 +1,299 @@
+package maas
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMaasMachines() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about the MAAS machines matching the given filters.",
+		ReadContext: dataSourceMachinesRead,
+
+		Schema: map[string]*schema.Schema{
+			"architecture": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the machines with this architecture (e.g. `amd64/generic`).",
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the machines in this domain.",
+			},
+			"hostname_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "Only return the machines whose hostname matches this regular expression.",
+			},
+			"machines": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of machines matching the filters, sorted by hostname.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"architecture": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The architecture type of the machine.",
+						},
+						"cpu_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of CPU cores of the machine.",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The domain of the machine.",
+						},
+						"fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The machine FQDN.",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The machine hostname.",
+						},
+						"ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "A list of IP addresses assigned to the machine.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"memory": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The RAM memory size of the machine (in MB).",
+						},
+						"owner": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user owning the machine, if it's allocated.",
+						},
+						"pool": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The resource pool of the machine.",
+						},
+						"power_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The power state of the machine (e.g. `on`).",
+						},
+						"power_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The power management type (e.g. `ipmi`) of the machine.",
+						},
+						"pxe_mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address of the machine's PXE boot NIC.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The machine status (e.g. `Ready`).",
+						},
+						"storage": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The total storage size of the machine (in MB).",
+						},
+						"system_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The machine system ID.",
+						},
+						"tags": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "A set of tag names assigned to the machine.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The zone of the machine.",
+						},
+					},
+				},
+			},
+			"min_cpu_count": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Only return the machines with at least this number of CPU cores.",
+			},
+			"min_memory": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Only return the machines with at least this RAM memory size (in MB).",
+			},
+			"not_tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only return the machines that don't have any of these tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the machines owned by this user.",
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the machines in this resource pool.",
+			},
+			"status": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only return the machines in one of these statuses (e.g. `Ready`, `Deployed`). The comparison is case insensitive.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"system_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of system IDs of the machines matching the filters, sorted by hostname.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only return the machines that have all of these tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the machines in this zone.",
+			},
+		},
+	}
+}
+
+func dataSourceMachinesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machines, err := client.Machines.Get(getMachinesParams(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var hostnameRegex *regexp.Regexp
+	if v, ok := d.GetOk("hostname_regex"); ok {
+		hostnameRegex = regexp.MustCompile(v.(string))
+	}
+	machines = filterMachines(machines, convertToStringSlice(d.Get("status").(*schema.Set).List()), hostnameRegex, d.Get("min_cpu_count").(int), int64(d.Get("min_memory").(int)))
+
+	systemIDs := make([]string, len(machines))
+	machinesState := make([]map[string]interface{}, len(machines))
+	for i, machine := range machines {
+		systemIDs[i] = machine.SystemID
+		ipAddresses := make([]string, len(machine.IPAddresses))
+		for j, ip := range machine.IPAddresses {
+			ipAddresses[j] = ip.String()
+		}
+		machinesState[i] = map[string]interface{}{
+			"system_id":       machine.SystemID,
+			"hostname":        machine.Hostname,
+			"fqdn":            machine.FQDN,
+			"domain":          machine.Domain.Name,
+			"zone":            machine.Zone.Name,
+			"pool":            machine.Pool.Name,
+			"architecture":    machine.Architecture,
+			"status":          machine.StatusName,
+			"owner":           machine.Owner,
+			"power_state":     machine.PowerState,
+			"power_type":      machine.PowerType,
+			"pxe_mac_address": machine.BootInterface.MACAddress,
+			"cpu_count":       machine.CPUCount,
+			"memory":          machine.Memory,
+			"storage":         int(machine.Storage),
+			"tags":            machine.TagNames,
+			"ip_addresses":    ipAddresses,
+		}
+	}
+
+	d.SetId(getListID(systemIDs))
+	tfState := map[string]interface{}{
+		"system_ids": systemIDs,
+		"machines":   machinesState,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getMachinesParams returns the filters that are applied by the MAAS server.
+func getMachinesParams(d *schema.ResourceData) *entity.MachinesParams {
+	params := &entity.MachinesParams{
+		Tags:    convertToStringSlice(d.Get("tags").(*schema.Set).List()),
+		NotTags: convertToStringSlice(d.Get("not_tags").(*schema.Set).List()),
+	}
+	if v, ok := d.GetOk("zone"); ok {
+		params.Zone = []string{v.(string)}
+	}
+	if v, ok := d.GetOk("pool"); ok {
+		params.Pool = []string{v.(string)}
+	}
+	if v, ok := d.GetOk("architecture"); ok {
+		params.Arch = []string{v.(string)}
+	}
+	if v, ok := d.GetOk("owner"); ok {
+		params.Owner = []string{v.(string)}
+	}
+	if v, ok := d.GetOk("domain"); ok {
+		params.Domain = []string{v.(string)}
+	}
+	return params
+}
+
+// filterMachines applies the filters that are not supported by the MAAS server,
+// and sorts the machines by hostname.
+func filterMachines(machines []entity.Machine, statuses []string, hostnameRegex *regexp.Regexp, minCPUCount int, minMemory int64) []entity.Machine {
+	result := []entity.Machine{}
+	for _, m := range machines {
+		if len(statuses) > 0 && !slices.ContainsFunc(statuses, func(s string) bool { return strings.EqualFold(s, m.StatusName) }) {
+			continue
+		}
+		if hostnameRegex != nil && !hostnameRegex.MatchString(m.Hostname) {
+			continue
+		}
+		if m.CPUCount < minCPUCount || m.Memory < minMemory {
+			continue
+		}
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Hostname < result[j].Hostname
+	})
+	return result
+}
@@ -0,0 +1,36 @@
+package maas_test
+
+import (
+	"fmt"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMaasMachines_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testutils.PreCheck(t, nil) },
+		Providers:  testutils.TestAccProviders,
+		ErrorCheck: func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMaasMachines("^tf-acc-no-such-machine$"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.maas_machines.test", "machines.#", "0"),
+					resource.TestCheckResourceAttr("data.maas_machines.test", "system_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMaasMachines(hostnameRegex string) string {
+	return fmt.Sprintf(`
+data "maas_machines" "test" {
+	hostname_regex = "%s"
+	status         = ["Ready"]
+	min_cpu_count  = 1
+}
+`, hostnameRegex)
+}
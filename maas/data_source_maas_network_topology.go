@@ -0,0 +1,268 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasNetworkTopology() *schema.Resource {
+	// VLANs are nested in fabrics, subnets in VLANs, and IP ranges in subnets
+	subnetSchema := getSubnetTFSchema()
+	subnetSchema["ip_ranges"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The list of IP ranges of the subnet.",
+		Elem: &schema.Resource{
+			Schema: getIPRangeTFSchema(),
+		},
+	}
+	vlanSchema := getVlanTFSchema()
+	vlanSchema["subnets"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The list of subnets on the VLAN.",
+		Elem: &schema.Resource{
+			Schema: subnetSchema,
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Provides the whole MAAS network model (fabrics, VLANs, subnets, IP ranges, spaces and rack controllers), fetched in bulk.",
+		ReadContext: dataSourceNetworkTopologyRead,
+
+		Schema: map[string]*schema.Schema{
+			"cidrs_by_vid": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map of comma-separated subnet CIDRs, keyed by VLAN traffic segregation ID. The subnets of the VLANs with the same VID on different fabrics are merged.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"fabrics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of fabrics, with their VLANs, subnets and IP ranges nested.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"class_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fabric class type.",
+						},
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The fabric ID.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fabric name.",
+						},
+						"vlans": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The list of VLANs on the fabric.",
+							Elem: &schema.Resource{
+								Schema: vlanSchema,
+							},
+						},
+					},
+				},
+			},
+			"rack_controllers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of rack controllers.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The rack controller FQDN.",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The rack controller hostname.",
+						},
+						"ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "A list of IP addresses assigned to the rack controller.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"system_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The rack controller system ID.",
+						},
+						"vlan_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The list of IDs of the VLANs the rack controller interfaces are connected to.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+					},
+				},
+			},
+			"spaces": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of spaces.",
+				Elem: &schema.Resource{
+					Schema: getSpaceTFSchema(),
+				},
+			},
+			"subnet_ids_by_cidr": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map of subnet IDs, keyed by subnet CIDR.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vids_by_cidr": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map of VLAN traffic segregation IDs, keyed by subnet CIDR.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkTopologyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	fabrics, err := client.Fabrics.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	subnets, err := client.Subnets.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ipRanges, err := client.IPRanges.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	spaces, err := client.Spaces.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rackControllers, err := client.RackControllers.Get(&entity.RackControllersGetParams{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfState := getNetworkTopologyTFState(fabrics, subnets, ipRanges, spaces, rackControllers)
+	ids := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		ids[i] = fmt.Sprintf("%v", subnet.ID)
+	}
+	d.SetId(getListID(ids))
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getNetworkTopologyTFState(fabrics []entity.Fabric, subnets []entity.Subnet, ipRanges []entity.IPRange, spaces []entity.Space, rackControllers []entity.RackController) map[string]interface{} {
+	ipRangesBySubnet := map[int][]map[string]interface{}{}
+	for _, ipRange := range ipRanges {
+		ipRangesBySubnet[ipRange.Subnet.ID] = append(ipRangesBySubnet[ipRange.Subnet.ID], getIPRangeTFState(&ipRange))
+	}
+
+	subnetsByVlan := map[int][]map[string]interface{}{}
+	subnetIDsByCIDR := map[string]string{}
+	vidsByCIDR := map[string]string{}
+	cidrsByVID := map[string][]string{}
+	for _, subnet := range subnets {
+		subnetState := getSubnetTFState(&subnet)
+		subnetState["ip_ranges"] = ipRangesBySubnet[subnet.ID]
+		subnetsByVlan[subnet.VLAN.ID] = append(subnetsByVlan[subnet.VLAN.ID], subnetState)
+
+		vid := fmt.Sprintf("%v", subnet.VLAN.VID)
+		subnetIDsByCIDR[subnet.CIDR] = fmt.Sprintf("%v", subnet.ID)
+		vidsByCIDR[subnet.CIDR] = vid
+		cidrsByVID[vid] = append(cidrsByVID[vid], subnet.CIDR)
+	}
+	cidrsByVIDState := make(map[string]string, len(cidrsByVID))
+	for vid, cidrs := range cidrsByVID {
+		sort.Strings(cidrs)
+		cidrsByVIDState[vid] = strings.Join(cidrs, ",")
+	}
+
+	fabricsState := make([]map[string]interface{}, len(fabrics))
+	for i, fabric := range fabrics {
+		vlans := make([]map[string]interface{}, len(fabric.VLANs))
+		for j, vlan := range fabric.VLANs {
+			vlans[j] = getVlanTFState(&vlan)
+			vlans[j]["subnets"] = subnetsByVlan[vlan.ID]
+		}
+		fabricState := getFabricTFState(&fabric)
+		delete(fabricState, "vids")
+		fabricState["vlans"] = vlans
+		fabricsState[i] = fabricState
+	}
+
+	spacesState := make([]map[string]interface{}, len(spaces))
+	for i, space := range spaces {
+		spacesState[i] = getSpaceTFState(&space)
+	}
+
+	rackControllersState := make([]map[string]interface{}, len(rackControllers))
+	for i, rackController := range rackControllers {
+		ipAddresses := make([]string, len(rackController.IPAddresses))
+		for j, ip := range rackController.IPAddresses {
+			ipAddresses[j] = ip.String()
+		}
+		rackControllersState[i] = map[string]interface{}{
+			"system_id":    rackController.SystemID,
+			"hostname":     rackController.Hostname,
+			"fqdn":         rackController.FQDN,
+			"ip_addresses": ipAddresses,
+			"vlan_ids":     getNetworkInterfacesVlanIDs(rackController.InterfaceSet),
+		}
+	}
+
+	return map[string]interface{}{
+		"fabrics":            fabricsState,
+		"spaces":             spacesState,
+		"rack_controllers":   rackControllersState,
+		"subnet_ids_by_cidr": subnetIDsByCIDR,
+		"vids_by_cidr":       vidsByCIDR,
+		"cidrs_by_vid":       cidrsByVIDState,
+	}
+}
+
+// getNetworkInterfacesVlanIDs returns the sorted IDs of the VLANs the network interfaces are connected to.
+func getNetworkInterfacesVlanIDs(networkInterfaces []entity.NetworkInterface) []int {
+	vlanIDs := []int{}
+	for _, networkInterface := range networkInterfaces {
+		if networkInterface.VLAN.ID == 0 {
+			continue
+		}
+		if !slices.Contains(vlanIDs, networkInterface.VLAN.ID) {
+			vlanIDs = append(vlanIDs, networkInterface.VLAN.ID)
+		}
+	}
+	sort.Ints(vlanIDs)
+	return vlanIDs
+}
@@ -0,0 +1,52 @@
+package maas
+
+import (
+	"net"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNetworkTopologyTFState(t *testing.T) {
+	vlan10 := entity.VLAN{ID: 5001, VID: 10, Name: "vlan10", Fabric: "fabric-0", FabricID: 1}
+	vlan20 := entity.VLAN{ID: 5002, VID: 20, Name: "vlan20", Fabric: "fabric-1", FabricID: 2}
+	fabrics := []entity.Fabric{
+		{ID: 1, Name: "fabric-0", VLANs: []entity.VLAN{vlan10}},
+		{ID: 2, Name: "fabric-1", VLANs: []entity.VLAN{vlan20}},
+	}
+	subnets := []entity.Subnet{
+		{ID: 1, CIDR: "10.0.10.0/24", VLAN: vlan10},
+		{ID: 2, CIDR: "10.0.11.0/24", VLAN: vlan10},
+		{ID: 3, CIDR: "10.0.20.0/24", VLAN: vlan20},
+	}
+	ipRanges := []entity.IPRange{
+		{ID: 1, Type: "dynamic", StartIP: net.ParseIP("10.0.10.100"), EndIP: net.ParseIP("10.0.10.200"), Subnet: subnets[0]},
+	}
+	rackControllers := []entity.RackController{
+		{SystemID: "abc123", InterfaceSet: []entity.NetworkInterface{{VLAN: vlan20}, {VLAN: vlan10}, {VLAN: vlan10}, {}}},
+	}
+
+	tfState := getNetworkTopologyTFState(fabrics, subnets, ipRanges, nil, rackControllers)
+
+	assert.Equal(t, map[string]string{"10.0.10.0/24": "1", "10.0.11.0/24": "2", "10.0.20.0/24": "3"}, tfState["subnet_ids_by_cidr"])
+	assert.Equal(t, map[string]string{"10.0.10.0/24": "10", "10.0.11.0/24": "10", "10.0.20.0/24": "20"}, tfState["vids_by_cidr"])
+	assert.Equal(t, map[string]string{"10": "10.0.10.0/24,10.0.11.0/24", "20": "10.0.20.0/24"}, tfState["cidrs_by_vid"])
+
+	fabricsState := tfState["fabrics"].([]map[string]interface{})
+	assert.Len(t, fabricsState, 2)
+	vlansState := fabricsState[0]["vlans"].([]map[string]interface{})
+	assert.Len(t, vlansState, 1)
+	subnetsState := vlansState[0]["subnets"].([]map[string]interface{})
+	assert.Len(t, subnetsState, 2)
+	assert.Len(t, subnetsState[0]["ip_ranges"], 1)
+	assert.Len(t, subnetsState[1]["ip_ranges"], 0)
+
+	rackControllersState := tfState["rack_controllers"].([]map[string]interface{})
+	assert.Equal(t, []int{5001, 5002}, rackControllersState[0]["vlan_ids"])
+
+	d := schema.TestResourceDataRaw(t, dataSourceMaasNetworkTopology().Schema, map[string]interface{}{})
+	assert.NoError(t, setTerraformState(d, tfState))
+	assert.Equal(t, "10.0.10.100", d.Get("fabrics.0.vlans.0.subnets.0.ip_ranges.0.start_ip"))
+}
@@ -9,50 +9,59 @@ import (
 )
 
 func dataSourceMaasRackController() *schema.Resource {
-	return &schema.Resource{
-		Description: "Provides details about an existing MAAS rack controller.",
-		ReadContext: resourceRackControllerRead,
-
-		Schema: map[string]*schema.Schema{
-			"description": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The description of the rack controller.",
-			},
-			"hostname": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The hostname of the rack controller.",
-			},
-			"services": {
-				Type:        schema.TypeSet,
-				Computed:    true,
-				Description: "The services running on the rack controller.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The name of the service.",
-						},
-						"status": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The status of the service.",
-						},
+	rackControllerSchema := getControllerTFSchema()
+	rackControllerSchema["boot_images"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The boot images available on the rack controller.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"architecture": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The architecture of the boot image.",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the boot image (e.g. `ubuntu/jammy`).",
+				},
+				"subarches": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The sub-architectures of the boot image.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
 				},
 			},
-			"version": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The MAAS version of the rack controller.",
-			},
 		},
 	}
+	rackControllerSchema["boot_images_connected"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Boolean value indicating if the rack controller is connected to the region, so that its boot images can be synchronized.",
+	}
+	rackControllerSchema["boot_images_status"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The boot images synchronization status of the rack controller (e.g. `synced`, `syncing`, `out-of-sync`).",
+	}
+	rackControllerSchema["hostname"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The hostname of the rack controller.",
+	}
+
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS rack controller.",
+		ReadContext: dataSourceRackControllerRead,
+
+		Schema: rackControllerSchema,
+	}
 }
 
-func resourceRackControllerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func dataSourceRackControllerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ClientConfig).Client
 
 	hostname := d.Get("hostname").(string)
@@ -66,19 +75,30 @@ func resourceRackControllerRead(ctx context.Context, d *schema.ResourceData, met
 	if len(rackControllers) == 0 {
 		return diag.Errorf("rack controller (%s) was not found", hostname)
 	}
-	d.SetId(rackControllers[0].SystemID)
-
-	d.Set("description", rackControllers[0].Description)
-	d.Set("version", rackControllers[0].Description)
+	rackController := rackControllers[0]
+	bootImages, err := getRackControllerBootImages(client, rackController.SystemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	services := make([]map[string]interface{}, len(rackControllers[0].ServiceSet))
-	for i, service := range rackControllers[0].ServiceSet {
-		services[i] = map[string]interface{}{
-			"name":   service.Name,
-			"status": service.Status,
+	images := make([]map[string]interface{}, len(bootImages.Images))
+	for i, image := range bootImages.Images {
+		images[i] = map[string]interface{}{
+			"name":         image.Name,
+			"architecture": image.Architecture,
+			"subarches":    image.Subarches,
 		}
 	}
-	if err := d.Set("services", services); err != nil {
+	fabrics, err := client.Fabrics.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := getControllerTFState(&rackController, fabrics)
+	tfState["id"] = rackController.SystemID
+	tfState["boot_images"] = images
+	tfState["boot_images_connected"] = bootImages.Connected
+	tfState["boot_images_status"] = bootImages.Status
+	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -0,0 +1,49 @@
+package maas
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasRegionController() *schema.Resource {
+	regionControllerSchema := getControllerTFSchema()
+	regionControllerSchema["hostname"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The hostname of the region controller.",
+	}
+
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS region controller.",
+		ReadContext: dataSourceRegionControllerRead,
+
+		Schema: regionControllerSchema,
+	}
+}
+
+func dataSourceRegionControllerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	hostname := d.Get("hostname").(string)
+	regionControllers, err := getRegionControllers(client, url.Values{"hostname": {hostname}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(regionControllers) == 0 {
+		return diag.Errorf("region controller (%s) was not found", hostname)
+	}
+	fabrics, err := client.Fabrics.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := getControllerTFState(&regionControllers[0], fabrics)
+	tfState["id"] = regionControllers[0].SystemID
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package maas_test
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMaasRegionController_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testutils.PreCheck(t, nil) },
+		Providers:  testutils.TestAccProviders,
+		ErrorCheck: func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceMaasRegionController("region-controller"),
+				ExpectError: regexp.MustCompile(`region controller \(region-controller\) was not found`),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMaasRegionController(hostname string) string {
+	return fmt.Sprintf(`
+data "maas_region_controller" "test" {
+	hostname = "%s"
+}
+`, hostname)
+}
@@ -0,0 +1,56 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasSpace() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS network space.",
+		ReadContext: dataSourceSpaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The space identifier (name or ID).",
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of CIDRs of the subnets in the space.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vlan_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of IDs of the VLANs in the space.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSpaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	space, err := getSpace(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := getSpaceTFState(space)
+	tfState["id"] = fmt.Sprintf("%v", space.ID)
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
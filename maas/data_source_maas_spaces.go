@@ -0,0 +1,98 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasSpaces() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about all the MAAS network spaces.",
+		ReadContext: dataSourceSpacesRead,
+
+		Schema: map[string]*schema.Schema{
+			"spaces": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of spaces.",
+				Elem: &schema.Resource{
+					Schema: getSpaceTFSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSpacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	spaces, err := client.Spaces.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := make([]string, len(spaces))
+	spacesState := make([]map[string]interface{}, len(spaces))
+	for i, space := range spaces {
+		ids[i] = fmt.Sprintf("%v", space.ID)
+		spacesState[i] = getSpaceTFState(&space)
+	}
+	d.SetId(getListID(ids))
+	if err := d.Set("spaces", spacesState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getSpaceTFSchema returns the schema of a space returned by the network data sources.
+func getSpaceTFSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The space ID.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The space name.",
+		},
+		"subnets": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The list of CIDRs of the subnets in the space.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"vlan_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The list of IDs of the VLANs in the space.",
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+	}
+}
+
+func getSpaceTFState(space *entity.Space) map[string]interface{} {
+	subnets := make([]string, len(space.Subnets))
+	for i, subnet := range space.Subnets {
+		subnets[i] = subnet.CIDR
+	}
+	vlanIDs := make([]int, len(space.VLANs))
+	for i, vlan := range space.VLANs {
+		vlanIDs[i] = vlan.ID
+	}
+	return map[string]interface{}{
+		"id":       space.ID,
+		"name":     space.Name,
+		"subnets":  subnets,
+		"vlan_ids": vlanIDs,
+	}
+}
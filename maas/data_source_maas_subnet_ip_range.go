@@ -0,0 +1,70 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasSubnetIPRange() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS IP range.",
+		ReadContext: dataSourceSubnetIPRangeRead,
+
+		Schema: map[string]*schema.Schema{
+			"comment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A description of the IP range.",
+			},
+			"end_ip": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The end IP address of the range.",
+			},
+			"start_ip": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The start IP address of the range.",
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the IP range subnet.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP range type (`dynamic` or `reserved`).",
+			},
+			"user": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who reserved the IP range.",
+			},
+		},
+	}
+}
+
+func dataSourceSubnetIPRangeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	ipRange, err := getSubnetIPRange(client, d.Get("start_ip").(string), d.Get("end_ip").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := map[string]interface{}{
+		"id":      fmt.Sprintf("%v", ipRange.ID),
+		"subnet":  fmt.Sprintf("%v", ipRange.Subnet.ID),
+		"type":    ipRange.Type,
+		"comment": ipRange.Comment,
+		"user":    ipRange.User.UserName,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
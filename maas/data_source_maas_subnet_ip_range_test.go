@@ -0,0 +1,61 @@
+package maas_test
+
+import (
+	"fmt"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMaasSubnetIPRange_basic(t *testing.T) {
+
+	var ipRange entity.IPRange
+	subnetName := acctest.RandomWithPrefix("tf-subnet-")
+	comment := "Test comment"
+
+	checks := []resource.TestCheckFunc{
+		testAccMAASSubnetIPRangeCheckExists("maas_subnet_ip_range.test", &ipRange),
+		resource.TestCheckResourceAttrPair("data.maas_subnet_ip_range.test", "id", "maas_subnet_ip_range.test", "id"),
+		resource.TestCheckResourceAttrPair("data.maas_subnet_ip_range.test", "subnet", "maas_subnet.test", "id"),
+		resource.TestCheckResourceAttr("data.maas_subnet_ip_range.test", "type", "reserved"),
+		resource.TestCheckResourceAttr("data.maas_subnet_ip_range.test", "comment", comment),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMAASSubnetIPRangeDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMaasSubnetIPRange(subnetName, comment),
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMaasSubnetIPRange(subnetName string, comment string) string {
+	return fmt.Sprintf(`
+resource "maas_subnet" "test" {
+	cidr = "10.88.89.0/26"
+	name = "%s"
+}
+
+resource "maas_subnet_ip_range" "test" {
+	subnet   = maas_subnet.test.id
+	type     = "reserved"
+	start_ip = "10.88.89.10"
+	end_ip   = "10.88.89.20"
+	comment  = "%s"
+}
+
+data "maas_subnet_ip_range" "test" {
+	start_ip = maas_subnet_ip_range.test.start_ip
+	end_ip   = maas_subnet_ip_range.test.end_ip
+}
+`, subnetName, comment)
+}
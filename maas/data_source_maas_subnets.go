@@ -0,0 +1,260 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMaasSubnets() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about the MAAS network subnets matching the given filters.",
+		ReadContext: dataSourceSubnetsRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the subnets on this fabric (ID or name).",
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the subnets in this space.",
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of subnets matching the filters.",
+				Elem: &schema.Resource{
+					Schema: getSubnetTFSchema(),
+				},
+			},
+			"vid": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 4094)),
+				Description:      "Only return the subnets on VLANs with this traffic segregation ID.",
+			},
+			"within_cidr": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsCIDRNetwork(0, 128)),
+				Description:      "Only return the subnets contained in this CIDR (e.g. `10.0.0.0/8`).",
+			},
+		},
+	}
+}
+
+func dataSourceSubnetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	subnets, err := client.Subnets.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := subnetsFilter{
+		space:      d.Get("space").(string),
+		withinCIDR: d.Get("within_cidr").(string),
+		fabricID:   -1,
+		vid:        -1,
+	}
+	if v, ok := d.GetOk("fabric"); ok {
+		fabric, err := getFabric(client, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		filter.fabricID = fabric.ID
+	}
+	if !d.GetRawConfig().GetAttr("vid").IsNull() {
+		filter.vid = d.Get("vid").(int)
+	}
+	subnets, err = filterSubnets(subnets, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, len(subnets))
+	subnetsState := make([]map[string]interface{}, len(subnets))
+	for i, subnet := range subnets {
+		ids[i] = fmt.Sprintf("%v", subnet.ID)
+		subnetsState[i] = getSubnetTFState(&subnet)
+	}
+	d.SetId(getListID(ids))
+	if err := d.Set("subnets", subnetsState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// subnetsFilter holds the filters of the `maas_subnets` data source.
+// The fabric ID and VID are ignored when they are negative.
+type subnetsFilter struct {
+	space      string
+	withinCIDR string
+	fabricID   int
+	vid        int
+}
+
+func filterSubnets(subnets []entity.Subnet, filter subnetsFilter) ([]entity.Subnet, error) {
+	var within *net.IPNet
+	if filter.withinCIDR != "" {
+		_, ipNet, err := net.ParseCIDR(filter.withinCIDR)
+		if err != nil {
+			return nil, err
+		}
+		within = ipNet
+	}
+	result := []entity.Subnet{}
+	for _, subnet := range subnets {
+		if filter.space != "" && subnet.Space != filter.space {
+			continue
+		}
+		if filter.fabricID >= 0 && subnet.VLAN.FabricID != filter.fabricID {
+			continue
+		}
+		if filter.vid >= 0 && subnet.VLAN.VID != filter.vid {
+			continue
+		}
+		if within != nil && !isCIDRWithin(subnet.CIDR, within) {
+			continue
+		}
+		result = append(result, subnet)
+	}
+	return result, nil
+}
+
+// isCIDRWithin returns true if the network given as CIDR is fully contained in the other network.
+func isCIDRWithin(cidr string, network *net.IPNet) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	ones, bits := ipNet.Mask.Size()
+	networkOnes, networkBits := network.Mask.Size()
+	return bits == networkBits && ones >= networkOnes && network.Contains(ipNet.IP)
+}
+
+// getSubnetTFSchema returns the schema of a subnet returned by the network data sources.
+func getSubnetTFSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"active_discovery": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Boolean value that indicates if active discovery is enabled on the subnet.",
+		},
+		"allow_dns": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Boolean value that indicates if the MAAS DNS resolution is enabled for the subnet.",
+		},
+		"allow_proxy": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Boolean value that indicates if `maas-proxy` allows requests from the subnet.",
+		},
+		"cidr": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The subnet CIDR.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The subnet description.",
+		},
+		"dns_servers": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "List of IP addresses set as DNS servers for the subnet.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"fabric": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The subnet fabric name.",
+		},
+		"fabric_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The subnet fabric ID.",
+		},
+		"gateway_ip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Gateway IP address for the subnet.",
+		},
+		"id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The subnet ID.",
+		},
+		"managed": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Boolean value that indicates if MAAS manages the subnet.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The subnet name.",
+		},
+		"rdns_mode": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "How reverse DNS is handled for the subnet (`0` - disabled, `1` - enabled, `2` - RFC2317).",
+		},
+		"space": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The subnet space.",
+		},
+		"vid": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The subnet VLAN traffic segregation ID.",
+		},
+		"vlan_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The subnet VLAN ID.",
+		},
+	}
+}
+
+func getSubnetTFState(subnet *entity.Subnet) map[string]interface{} {
+	gatewayIp := ""
+	if subnet.GatewayIP != nil {
+		gatewayIp = subnet.GatewayIP.String()
+	}
+	dnsServers := make([]string, len(subnet.DNSServers))
+	for i, ip := range subnet.DNSServers {
+		dnsServers[i] = ip.String()
+	}
+	return map[string]interface{}{
+		"id":               subnet.ID,
+		"cidr":             subnet.CIDR,
+		"name":             subnet.Name,
+		"description":      subnet.Description,
+		"fabric":           subnet.VLAN.Fabric,
+		"fabric_id":        subnet.VLAN.FabricID,
+		"vid":              subnet.VLAN.VID,
+		"vlan_id":          subnet.VLAN.ID,
+		"space":            subnet.Space,
+		"gateway_ip":       gatewayIp,
+		"dns_servers":      dnsServers,
+		"rdns_mode":        subnet.RDNSMode,
+		"allow_dns":        subnet.AllowDNS,
+		"allow_proxy":      subnet.AllowProxy,
+		"managed":          subnet.Managed,
+		"active_discovery": subnet.ActiveDiscovery,
+	}
+}
@@ -0,0 +1,54 @@
+package maas_test
+
+import (
+	"fmt"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMaasSubnets_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-subnets-")
+
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttr("data.maas_subnets.test", "subnets.#", "1"),
+		resource.TestCheckResourceAttr("data.maas_subnets.test", "subnets.0.cidr", "10.77.77.0/26"),
+		resource.TestCheckResourceAttr("data.maas_subnets.test", "subnets.0.name", name),
+		resource.TestCheckResourceAttr("data.maas_subnets.test", "subnets.0.gateway_ip", "10.77.77.1"),
+		resource.TestCheckResourceAttr("data.maas_subnets.test", "subnets.0.fabric", name),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testutils.PreCheck(t, nil) },
+		Providers:  testutils.TestAccProviders,
+		ErrorCheck: func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMaasSubnets(name),
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMaasSubnets(name string) string {
+	return fmt.Sprintf(`
+resource "maas_fabric" "test" {
+	name = "%s"
+}
+
+resource "maas_subnet" "test" {
+	cidr       = "10.77.77.0/26"
+	name       = "%s"
+	fabric     = maas_fabric.test.id
+	gateway_ip = "10.77.77.1"
+}
+
+data "maas_subnets" "test" {
+	fabric      = maas_subnet.test.fabric
+	within_cidr = "10.77.0.0/16"
+}
+`, name, name)
+}
@@ -0,0 +1,75 @@
+package maas
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasTag() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS tag.",
+		ReadContext: dataSourceTagRead,
+
+		Schema: map[string]*schema.Schema{
+			"comment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The comment of the tag.",
+			},
+			"definition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The XPATH definition used to automatically tag the machines.",
+			},
+			"kernel_opts": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kernel options used when booting the machines with the tag.",
+			},
+			"machines": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The set of system IDs of the machines with the tag.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The tag name.",
+			},
+		},
+	}
+}
+
+func dataSourceTagRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	tag, err := getTag(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machines, err := client.Tag.GetMachines(tag.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machinesSystemIDs := make([]string, len(machines))
+	for i, machine := range machines {
+		machinesSystemIDs[i] = machine.SystemID
+	}
+	tfState := map[string]interface{}{
+		"id":          tag.Name,
+		"comment":     tag.Comment,
+		"definition":  tag.Definition,
+		"kernel_opts": tag.KernelOpts,
+		"machines":    machinesSystemIDs,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
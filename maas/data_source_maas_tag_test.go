@@ -0,0 +1,53 @@
+package maas_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceMaasTag_basic(t *testing.T) {
+
+	var tag entity.Tag
+	comment := "Test comment"
+	name := acctest.RandomWithPrefix("tf-tag-")
+	machines := os.Getenv("TF_ACC_TAG_MACHINES")
+
+	checks := []resource.TestCheckFunc{
+		testAccMaasTagCheckExists("maas_tag.test", &tag),
+		resource.TestCheckResourceAttr("data.maas_tag.test", "name", name),
+		resource.TestCheckResourceAttr("data.maas_tag.test", "comment", comment),
+		resource.TestCheckResourceAttr("data.maas_tag.test", "kernel_opts", "console=tty1 console=ttyS0"),
+		resource.TestCheckResourceAttr("data.maas_tag.test", "machines.#", strconv.Itoa(len(strings.Split(machines, ",")))),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, []string{"TF_ACC_TAG_MACHINES"}) },
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMaasTagDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMaasTag(name, comment, machines),
+				Check:  resource.ComposeTestCheckFunc(checks...),
+			},
+		},
+	})
+}
+
+func testAccDataSourceMaasTag(name string, comment string, machines string) string {
+	return fmt.Sprintf(`
+%s
+
+data "maas_tag" "test" {
+	name = maas_tag.test.name
+}
+`, testAccMaasTag(name, comment, machines))
+}
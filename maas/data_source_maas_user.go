@@ -0,0 +1,58 @@
+package maas
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS user.",
+		ReadContext: dataSourceUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user e-mail address.",
+			},
+			"is_admin": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if the user is a MAAS administrator.",
+			},
+			"is_local": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Boolean value indicating if the user is managed by MAAS, rather than by an external authentication source.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The user name.",
+			},
+		},
+	}
+}
+
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	user, err := getUser(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := map[string]interface{}{
+		"id":       user.UserName,
+		"email":    user.Email,
+		"is_admin": user.IsSuperUser,
+		"is_local": user.IsLocal,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
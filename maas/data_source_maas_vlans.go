@@ -0,0 +1,135 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasVlans() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about all the VLANs of an existing MAAS network fabric.",
+		ReadContext: dataSourceVlansRead,
+
+		Schema: map[string]*schema.Schema{
+			"fabric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The fabric identifier (ID or name) for the VLANs.",
+			},
+			"vlans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of VLANs on the fabric.",
+				Elem: &schema.Resource{
+					Schema: getVlanTFSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVlansRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vlans, err := client.VLANs.Get(fabric.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := make([]string, len(vlans))
+	vlansState := make([]map[string]interface{}, len(vlans))
+	for i, vlan := range vlans {
+		ids[i] = fmt.Sprintf("%v", vlan.ID)
+		vlansState[i] = getVlanTFState(&vlan)
+	}
+	d.SetId(getListID(ids))
+	if err := d.Set("vlans", vlansState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getVlanTFSchema returns the schema of a VLAN returned by the network data sources.
+func getVlanTFSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VLAN description.",
+		},
+		"dhcp_on": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Boolean value indicating if DHCP is enabled on the VLAN.",
+		},
+		"fabric": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VLAN fabric name.",
+		},
+		"fabric_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VLAN fabric ID.",
+		},
+		"id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VLAN ID.",
+		},
+		"mtu": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The MTU used on the VLAN.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VLAN name.",
+		},
+		"primary_rack": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The system ID of the primary rack controller serving DHCP on the VLAN.",
+		},
+		"secondary_rack": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The system ID of the secondary rack controller serving DHCP on the VLAN.",
+		},
+		"space": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VLAN space.",
+		},
+		"vid": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VLAN traffic segregation ID.",
+		},
+	}
+}
+
+func getVlanTFState(vlan *entity.VLAN) map[string]interface{} {
+	return map[string]interface{}{
+		"id":             vlan.ID,
+		"vid":            vlan.VID,
+		"name":           vlan.Name,
+		"description":    vlan.Description,
+		"mtu":            vlan.MTU,
+		"dhcp_on":        vlan.DHCPOn,
+		"space":          vlan.Space,
+		"fabric":         vlan.Fabric,
+		"fabric_id":      vlan.FabricID,
+		"primary_rack":   vlan.PrimaryRack,
+		"secondary_rack": vlan.SecondaryRack,
+	}
+}
@@ -0,0 +1,61 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasVMCluster() *schema.Resource {
+	vmClusterSchema := getVMClusterTFSchema()
+	vmClusterSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The VM cluster identifier (name or ID).",
+	}
+	vmClusterSchema["pool"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The VM cluster pool name.",
+	}
+	vmClusterSchema["project"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The LXD project used by MAAS to manage the VMs of the cluster.",
+	}
+	vmClusterSchema["zone"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The VM cluster zone name.",
+	}
+
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS VM cluster, including its members and their aggregate capacity.",
+		ReadContext: dataSourceVMClusterRead,
+
+		Schema: vmClusterSchema,
+	}
+}
+
+func dataSourceVMClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	cluster, err := getVMCluster(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vmHosts, err := getVMClusterHosts(client, cluster)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := getVMClusterTFState(cluster, vmHosts)
+	delete(tfState, "name")
+	tfState["id"] = fmt.Sprintf("%v", cluster.ID)
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasVMHost() *schema.Resource {
+	vmHostSchema := getVMHostTFSchema()
+	vmHostSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The VM host identifier (name or ID).",
+	}
+
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS VM host, including its capacity.",
+		ReadContext: dataSourceVMHostRead,
+
+		Schema: vmHostSchema,
+	}
+}
+
+func dataSourceVMHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	vmHost, err := getVMHost(client, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machines, err := client.Machines.Get(&entity.MachinesParams{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tfState := getVMHostTFState(vmHost, machines)
+	tfState["id"] = fmt.Sprintf("%v", vmHost.ID)
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMaasVMHostMachine() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides details about an existing MAAS VM host machine.",
+		ReadContext: dataSourceVMHostMachineRead,
+
+		Schema: map[string]*schema.Schema{
+			"cores": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of CPU cores of the VM host machine.",
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VM host machine domain.",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VM host machine hostname.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The VM host machine identifier (system ID, hostname, or FQDN).",
+			},
+			"memory": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The VM host machine RAM memory (in MB).",
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VM host machine pool.",
+			},
+			"power_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The power state of the VM host machine (e.g. `on`).",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VM host machine status (e.g. `Ready`).",
+			},
+			"vm_host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the VM host the machine is composed on.",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VM host machine zone.",
+			},
+		},
+	}
+}
+
+func dataSourceVMHostMachineRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	identifier := d.Get("machine").(string)
+	machine, err := getMachine(client, identifier)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !isVMHostMachine(machine) {
+		return diag.Errorf("machine (%s) is not a VM host machine", identifier)
+	}
+	tfState := map[string]interface{}{
+		"id":          machine.SystemID,
+		"vm_host":     fmt.Sprintf("%v", machine.VMHost.ID),
+		"hostname":    machine.Hostname,
+		"domain":      machine.Domain.Name,
+		"zone":        machine.Zone.Name,
+		"pool":        machine.Pool.Name,
+		"cores":       machine.CPUCount,
+		"memory":      machine.Memory,
+		"status":      machine.StatusName,
+		"power_state": machine.PowerState,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// isVMHostMachine reports whether the machine is composed on a VM host.
+func isVMHostMachine(machine *entity.Machine) bool {
+	return machine.VMHost != nil && machine.VMHost.ID != 0 && machine.VMHost.Name != "" && machine.VMHost.ResourceURI != ""
+}
@@ -0,0 +1,37 @@
+package maas
+
+import (
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsVMHostMachine(t *testing.T) {
+	tests := []struct {
+		name     string
+		machine  *entity.Machine
+		expected bool
+	}{
+		{
+			name:     "not a VM",
+			machine:  &entity.Machine{SystemID: "abc123"},
+			expected: false,
+		},
+		{
+			name:     "empty VM host",
+			machine:  &entity.Machine{SystemID: "abc123", VMHost: &entity.MachineVMHost{}},
+			expected: false,
+		},
+		{
+			name:     "VM host machine",
+			machine:  &entity.Machine{SystemID: "abc123", VMHost: &entity.MachineVMHost{ID: 1, Name: "kvm-host-01", ResourceURI: "/MAAS/api/2.0/pods/1/"}},
+			expected: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isVMHostMachine(tc.machine))
+		})
+	}
+}
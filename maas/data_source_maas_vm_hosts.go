@@ -0,0 +1,416 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMaasVMHosts() *schema.Resource {
+	vmHostSchema := getVMHostTFSchema()
+	vmHostSchema["id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The VM host ID.",
+	}
+	vmHostSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The VM host name.",
+	}
+
+	return &schema.Resource{
+		Description: "Provides details about the MAAS VM hosts matching the given filters, including their capacity.",
+		ReadContext: dataSourceVMHostsRead,
+
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of IDs of the VM hosts matching the filters, sorted by name.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the VM hosts in this resource pool.",
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"lxd", "virsh"}, false)),
+				Description:      "Only return the VM hosts of this type. Supported values are: `lxd`, `virsh`.",
+			},
+			"vm_hosts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of VM hosts matching the filters, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: vmHostSchema,
+				},
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the VM hosts in this zone.",
+			},
+		},
+	}
+}
+
+func dataSourceVMHostsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	vmHosts, err := client.VMHosts.Get()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machines, err := client.Machines.Get(&entity.MachinesParams{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(vmHosts, func(i, j int) bool {
+		return vmHosts[i].Name < vmHosts[j].Name
+	})
+
+	ids := []int{}
+	vmHostIDs := []string{}
+	vmHostsState := []map[string]interface{}{}
+	for _, vmHost := range vmHosts {
+		if v, ok := d.GetOk("type"); ok && v.(string) != vmHost.Type {
+			continue
+		}
+		if v, ok := d.GetOk("zone"); ok && v.(string) != vmHost.Zone.Name {
+			continue
+		}
+		if v, ok := d.GetOk("pool"); ok && v.(string) != vmHost.Pool.Name {
+			continue
+		}
+		vmHostState := getVMHostTFState(&vmHost, machines)
+		vmHostState["id"] = vmHost.ID
+		vmHostState["name"] = vmHost.Name
+		ids = append(ids, vmHost.ID)
+		vmHostIDs = append(vmHostIDs, fmt.Sprintf("%v", vmHost.ID))
+		vmHostsState = append(vmHostsState, vmHostState)
+	}
+
+	d.SetId(getListID(vmHostIDs))
+	tfState := map[string]interface{}{
+		"ids":      ids,
+		"vm_hosts": vmHostsState,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getVMHostTFSchema returns the schema of a VM host returned by the VM host data sources.
+func getVMHostTFSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cpu_over_commit_ratio": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The VM host CPU overcommit ratio.",
+		},
+		"default_macvlan_mode": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VM host default macvlan mode.",
+		},
+		"machine": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The system ID of the MAAS machine the VM host is deployed on, if any.",
+		},
+		"machines": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The list of machines composed on the VM host, sorted by hostname.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cpu_count": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of CPU cores of the machine.",
+					},
+					"hostname": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The machine hostname.",
+					},
+					"memory": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The RAM memory size of the machine (in MB).",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The machine status (e.g. `Ready`).",
+					},
+					"system_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The machine system ID.",
+					},
+				},
+			},
+		},
+		"memory_over_commit_ratio": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The VM host RAM memory overcommit ratio.",
+		},
+		"numa_nodes": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The NUMA nodes of the VM host. This is only available when the VM host is deployed on a MAAS machine.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cores": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The list of indexes of the CPU cores of the NUMA node.",
+						Elem: &schema.Schema{
+							Type: schema.TypeInt,
+						},
+					},
+					"hugepages": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The hugepages configured on the NUMA node.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"page_size": {
+									Type:        schema.TypeInt,
+									Computed:    true,
+									Description: "The hugepage size (in bytes).",
+								},
+								"total": {
+									Type:        schema.TypeInt,
+									Computed:    true,
+									Description: "The total hugepages memory (in bytes).",
+								},
+							},
+						},
+					},
+					"index": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The NUMA node index.",
+					},
+					"memory": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The RAM memory of the NUMA node (in MB).",
+					},
+				},
+			},
+		},
+		"pool": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VM host pool name.",
+		},
+		"resources_cores_available": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host number of available CPU cores.",
+		},
+		"resources_cores_total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host total number of CPU cores.",
+		},
+		"resources_cores_used": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host number of CPU cores used by the composed machines.",
+		},
+		"resources_local_storage_available": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host available local storage (in bytes).",
+		},
+		"resources_local_storage_total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host total local storage (in bytes).",
+		},
+		"resources_local_storage_used": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host local storage used by the composed machines (in bytes).",
+		},
+		"resources_memory_available": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host available RAM memory (in MB).",
+		},
+		"resources_memory_total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host total RAM memory (in MB).",
+		},
+		"resources_memory_used": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The VM host RAM memory used by the composed machines (in MB).",
+		},
+		"storage_pools": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The storage pools of the VM host.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"available": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The available storage of the pool (in bytes).",
+					},
+					"default": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Boolean value indicating if this is the default storage pool of the VM host.",
+					},
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The storage pool ID.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The storage pool name.",
+					},
+					"path": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The storage pool path.",
+					},
+					"total": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The total storage of the pool (in bytes).",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The storage pool type (e.g. `dir`, `zfs`, `lvm`).",
+					},
+					"used": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The storage of the pool used by the composed machines (in bytes).",
+					},
+				},
+			},
+		},
+		"tags": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "A set of tag names assigned to the VM host.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VM host type (`lxd` or `virsh`).",
+		},
+		"zone": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The VM host zone name.",
+		},
+	}
+}
+
+// getVMHostTFState returns the state of a VM host. The machines are used to find the machines
+// composed on the VM host, and the NUMA nodes of the machine the VM host is deployed on.
+func getVMHostTFState(vmHost *entity.VMHost, machines []entity.Machine) map[string]interface{} {
+	hostedMachines := []map[string]interface{}{}
+	numaNodes := []map[string]interface{}{}
+	for _, machine := range machines {
+		if machine.VMHost != nil && machine.VMHost.ID == vmHost.ID {
+			hostedMachines = append(hostedMachines, map[string]interface{}{
+				"system_id": machine.SystemID,
+				"hostname":  machine.Hostname,
+				"cpu_count": machine.CPUCount,
+				"memory":    machine.Memory,
+				"status":    machine.StatusName,
+			})
+		}
+		if vmHost.Host.SystemID != "" && machine.SystemID == vmHost.Host.SystemID {
+			for _, numaNode := range machine.NUMANodeSet {
+				hugepages := make([]map[string]interface{}, len(numaNode.HugepagesSet))
+				for i, h := range numaNode.HugepagesSet {
+					hugepages[i] = map[string]interface{}{
+						"page_size": h.PageSize,
+						"total":     h.Total,
+					}
+				}
+				numaNodes = append(numaNodes, map[string]interface{}{
+					"index":     numaNode.Index,
+					"cores":     numaNode.Cores,
+					"memory":    numaNode.Memory,
+					"hugepages": hugepages,
+				})
+			}
+		}
+	}
+
+	sort.Slice(hostedMachines, func(i, j int) bool {
+		return hostedMachines[i]["hostname"].(string) < hostedMachines[j]["hostname"].(string)
+	})
+
+	return map[string]interface{}{
+		"type":                              vmHost.Type,
+		"machine":                           vmHost.Host.SystemID,
+		"zone":                              vmHost.Zone.Name,
+		"pool":                              vmHost.Pool.Name,
+		"tags":                              vmHost.Tags,
+		"cpu_over_commit_ratio":             vmHost.CPUOverCommitRatio,
+		"memory_over_commit_ratio":          vmHost.MemoryOverCommitRatio,
+		"default_macvlan_mode":              vmHost.DefaultMACVLANMode,
+		"resources_cores_total":             vmHost.Total.Cores,
+		"resources_cores_used":              vmHost.Used.Cores,
+		"resources_cores_available":         vmHost.Available.Cores,
+		"resources_memory_total":            vmHost.Total.Memory,
+		"resources_memory_used":             vmHost.Used.Memory,
+		"resources_memory_available":        vmHost.Available.Memory,
+		"resources_local_storage_total":     vmHost.Total.LocalStorage,
+		"resources_local_storage_used":      vmHost.Used.LocalStorage,
+		"resources_local_storage_available": vmHost.Available.LocalStorage,
+		"storage_pools":                     getVMHostStoragePoolsTFState(vmHost.StoragePools),
+		"machines":                          hostedMachines,
+		"numa_nodes":                        numaNodes,
+	}
+}
+
+func getVMHostStoragePoolsTFState(storagePools []entity.VMHostStoragePool) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(storagePools))
+	for i, storagePool := range storagePools {
+		result[i] = map[string]interface{}{
+			"id":        storagePool.ID,
+			"name":      storagePool.Name,
+			"type":      storagePool.Type,
+			"path":      storagePool.Path,
+			"total":     storagePool.Total,
+			"used":      storagePool.Used,
+			"available": storagePool.Available,
+			"default":   storagePool.Default,
+		}
+	}
+	return result
+}
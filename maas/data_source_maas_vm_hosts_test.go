@@ -0,0 +1,51 @@
+package maas
+
+import (
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVMHostTFState(t *testing.T) {
+	vmHost := &entity.VMHost{
+		ID:   1,
+		Name: "kvm-host-01",
+		Type: "lxd",
+		StoragePools: []entity.VMHostStoragePool{
+			{ID: "pool1", Name: "default", Type: "zfs", Path: "/var/lib/lxd", Total: 100, Used: 40, Available: 60, Default: true},
+		},
+		Total:     entity.VMHostResource{Cores: 16, Memory: 32768, LocalStorage: 100},
+		Used:      entity.VMHostResource{Cores: 4, Memory: 8192, LocalStorage: 40},
+		Available: entity.VMHostResource{Cores: 12, Memory: 24576, LocalStorage: 60},
+	}
+	vmHost.Host.SystemID = "host01"
+	machines := []entity.Machine{
+		{SystemID: "vm0002", Hostname: "vm-b", CPUCount: 2, Memory: 4096, StatusName: "Deployed", VMHost: &entity.MachineVMHost{ID: 1}},
+		{SystemID: "other1", Hostname: "vm-c", CPUCount: 1, Memory: 1024, StatusName: "Ready", VMHost: &entity.MachineVMHost{ID: 2}},
+		{SystemID: "vm0001", Hostname: "vm-a", CPUCount: 2, Memory: 4096, StatusName: "Ready", VMHost: &entity.MachineVMHost{ID: 1}},
+		{
+			SystemID:   "host01",
+			Hostname:   "kvm-host-01",
+			StatusName: "Deployed",
+			NUMANodeSet: []entity.NUMANode{
+				{Index: 0, Cores: []int{0, 1, 2, 3}, Memory: 16384, HugepagesSet: []entity.NUMANodeHugepages{{PageSize: 2097152, Total: 1073741824}}},
+			},
+		},
+	}
+
+	tfState := getVMHostTFState(vmHost, machines)
+	assert.Equal(t, "host01", tfState["machine"])
+	assert.Equal(t, 4, tfState["resources_cores_used"])
+	assert.Equal(t, int64(24576), tfState["resources_memory_available"])
+	assert.Equal(t, []map[string]interface{}{
+		{"system_id": "vm0001", "hostname": "vm-a", "cpu_count": 2, "memory": int64(4096), "status": "Ready"},
+		{"system_id": "vm0002", "hostname": "vm-b", "cpu_count": 2, "memory": int64(4096), "status": "Deployed"},
+	}, tfState["machines"])
+	assert.Equal(t, []map[string]interface{}{
+		{"index": 0, "cores": []int{0, 1, 2, 3}, "memory": 16384, "hugepages": []map[string]interface{}{{"page_size": 2097152, "total": 1073741824}}},
+	}, tfState["numa_nodes"])
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "pool1", "name": "default", "type": "zfs", "path": "/var/lib/lxd", "total": int64(100), "used": int64(40), "available": int64(60), "default": true},
+	}, tfState["storage_pools"])
+}
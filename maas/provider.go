@@ -56,9 +56,11 @@ func Provider() *schema.Provider {
 			"maas_boot_source":                resourceMAASBootSource(),
 			"maas_device":                     resourceMaasDevice(),
 			"maas_instance":                   resourceMaasInstance(),
+			"maas_vm_cluster":                 resourceMaasVMCluster(),
 			"maas_vm_host":                    resourceMaasVMHost(),
 			"maas_vm_host_machine":            resourceMaasVMHostMachine(),
 			"maas_machine":                    resourceMaasMachine(),
+			"maas_machine_annotations":        resourceMaasMachineAnnotations(),
 			"maas_network_interface_bridge":   resourceMaasNetworkInterfaceBridge(),
 			"maas_network_interface_bond":     resourceMaasNetworkInterfaceBond(),
 			"maas_network_interface_physical": resourceMaasNetworkInterfacePhysical(),
@@ -73,6 +75,11 @@ func Provider() *schema.Provider {
 			"maas_space":                      resourceMaasSpace(),
 			"maas_block_device":               resourceMaasBlockDevice(),
 			"maas_block_device_tag":           resourceMaasBlockDeviceTag(),
+			"maas_raid":                       resourceMaasRAID(),
+			"maas_volume_group":               resourceMaasVolumeGroup(),
+			"maas_logical_volume":             resourceMaasLogicalVolume(),
+			"maas_bcache_cache_set":           resourceMaasBCacheCacheSet(),
+			"maas_bcache":                     resourceMaasBCache(),
 			"maas_tag":                        resourceMaasTag(),
 			"maas_network_interface_tag":      resourceMaasNetworkInterfaceTag(),
 			"maas_user":                       resourceMaasUser(),
@@ -83,13 +90,34 @@ func Provider() *schema.Provider {
 			"maas_boot_source":                dataSourceMaasBootSource(),
 			"maas_boot_source_selection":      dataSourceMaasBootSourceSelection(),
 			"maas_fabric":                     dataSourceMaasFabric(),
+			"maas_fabrics":                    dataSourceMaasFabrics(),
 			"maas_vlan":                       dataSourceMaasVlan(),
+			"maas_vlans":                      dataSourceMaasVlans(),
 			"maas_subnet":                     dataSourceMaasSubnet(),
+			"maas_subnets":                    dataSourceMaasSubnets(),
+			"maas_subnet_ip_range":            dataSourceMaasSubnetIPRange(),
+			"maas_ip_ranges":                  dataSourceMaasIPRanges(),
+			"maas_space":                      dataSourceMaasSpace(),
+			"maas_spaces":                     dataSourceMaasSpaces(),
+			"maas_dns_domain":                 dataSourceMaasDnsDomain(),
+			"maas_dns_domains":                dataSourceMaasDnsDomains(),
+			"maas_dns_record":                 dataSourceMaasDnsRecord(),
+			"maas_network_topology":           dataSourceMaasNetworkTopology(),
 			"maas_machine":                    dataSourceMaasMachine(),
+			"maas_machines":                   dataSourceMaasMachines(),
+			"maas_block_device":               dataSourceMaasBlockDevice(),
+			"maas_vm_cluster":                 dataSourceMaasVMCluster(),
+			"maas_vm_host":                    dataSourceMaasVMHost(),
+			"maas_vm_hosts":                   dataSourceMaasVMHosts(),
+			"maas_vm_host_machine":            dataSourceMaasVMHostMachine(),
 			"maas_network_interface_physical": dataSourceMaasNetworkInterfacePhysical(),
 			"maas_device":                     dataSourceMaasDevice(),
 			"maas_resource_pool":              dataSourceMaasResourcePool(),
+			"maas_tag":                        dataSourceMaasTag(),
+			"maas_user":                       dataSourceMaasUser(),
 			"maas_rack_controller":            dataSourceMaasRackController(),
+			"maas_region_controller":          dataSourceMaasRegionController(),
+			"maas_controllers":                dataSourceMaasControllers(),
 			"maas_zone":                       dataSourceMaasZone(),
 		},
 		ConfigureContextFunc: providerConfigure,
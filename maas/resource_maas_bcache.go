@@ -0,0 +1,258 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMaasBCache() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS machines' bcache devices.",
+		CreateContext: resourceBCacheCreate,
+		ReadContext:   resourceBCacheRead,
+		UpdateContext: resourceBCacheUpdate,
+		DeleteContext: resourceBCacheDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:BCACHE", d.Id())
+				}
+				client := meta.(*ClientConfig).Client
+
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				bcache, err := getBCache(client, machine.SystemID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", bcache.ID),
+					"machine": machine.SystemID,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"backing_device": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"backing_device", "backing_partition"},
+				Description:  "The block device (ID, name, ID path or path) used as backing device, usually a HDD.",
+			},
+			"backing_partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"backing_device", "backing_partition"},
+				Description:  "The partition (ID, name or path) used as backing device.",
+			},
+			"block_device_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the block device of the bcache.",
+			},
+			"cache_mode": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"writeback", "writethrough", "writearound"}, false)),
+				Description:      "The cache mode. Supported values are: `writeback`, `writethrough`, `writearound`.",
+			},
+			"cache_set": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the cache set used by the bcache.",
+			},
+			"fs_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file system type (e.g. `ext4`) of the bcache. If this is not set, the bcache is unformatted.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The machine identifier (system ID, hostname, or FQDN) that owns the bcache.",
+			},
+			"mount_options": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"mount_point"},
+				Description:  "The options used for the bcache mount.",
+			},
+			"mount_point": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"fs_type"},
+				Description:  "The mount point used. If this is not set, the bcache is not mounted. This is used only if the bcache is formatted.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The bcache name (e.g. `bcache0`).",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the block device of the bcache.",
+			},
+			"size_gigabytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the bcache (given in GB).",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The bcache UUID.",
+			},
+		},
+	}
+}
+
+func resourceBCacheCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	backingDevice, backingPartition, err := getStorageMemberID(client, machine.SystemID, d, "backing_device", "backing_partition")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := entity.BCacheParams{
+		Name:             d.Get("name").(string),
+		CacheSet:         d.Get("cache_set").(string),
+		CacheMode:        d.Get("cache_mode").(string),
+		BackingDevice:    backingDevice,
+		BackingPartition: backingPartition,
+	}
+	bcache, err := client.BCaches.Create(machine.SystemID, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", bcache.ID))
+
+	if err := updateBlockDeviceFilesystem(client, machine.SystemID, &bcache.VirtualDevice, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBCacheRead(ctx, d, meta)
+}
+
+func resourceBCacheRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bcache, err := client.BCache.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backingDevice, backingPartition := getStorageMemberTFState(&bcache.BackingDevice, d.Get("backing_device").(string), d.Get("backing_partition").(string))
+	tfState := map[string]interface{}{
+		"name":              bcache.Name,
+		"uuid":              bcache.UUID,
+		"cache_mode":        bcache.CacheMode,
+		"cache_set":         fmt.Sprintf("%v", bcache.CacheSet.ID),
+		"backing_device":    backingDevice,
+		"backing_partition": backingPartition,
+		"size_gigabytes":    int(bcache.Size / (1024 * 1024 * 1024)),
+		"block_device_id":   bcache.VirtualDevice.ID,
+		"path":              bcache.VirtualDevice.Path,
+		"fs_type":           bcache.VirtualDevice.Filesystem.FSType,
+		"mount_point":       bcache.VirtualDevice.Filesystem.MountPoint,
+		"mount_options":     bcache.VirtualDevice.Filesystem.MountOptions,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceBCacheUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := entity.BCacheParams{
+		Name:      d.Get("name").(string),
+		CacheMode: d.Get("cache_mode").(string),
+	}
+	if d.HasChange("cache_set") {
+		params.CacheSet = d.Get("cache_set").(string)
+	}
+	if d.HasChanges("backing_device", "backing_partition") {
+		if params.BackingDevice, params.BackingPartition, err = getStorageMemberID(client, machine.SystemID, d, "backing_device", "backing_partition"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	bcache, err := client.BCache.Update(machine.SystemID, id, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateBlockDeviceFilesystem(client, machine.SystemID, &bcache.VirtualDevice, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBCacheRead(ctx, d, meta)
+}
+
+func resourceBCacheDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.BCache.Delete(machine.SystemID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getBCache(client *client.Client, machineID string, identifier string) (*entity.BCache, error) {
+	bcaches, err := client.BCaches.Get(machineID)
+	if err != nil {
+		return nil, err
+	}
+	for _, bcache := range bcaches {
+		if fmt.Sprintf("%v", bcache.ID) == identifier || bcache.Name == identifier {
+			return &bcache, nil
+		}
+	}
+	return nil, fmt.Errorf("bcache (%s) was not found on machine (%s)", identifier, machineID)
+}
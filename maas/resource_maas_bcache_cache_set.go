@@ -0,0 +1,207 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMaasBCacheCacheSet() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS machines' bcache cache sets.",
+		CreateContext: resourceBCacheCacheSetCreate,
+		ReadContext:   resourceBCacheCacheSetRead,
+		UpdateContext: resourceBCacheCacheSetUpdate,
+		DeleteContext: resourceBCacheCacheSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:CACHE_SET_ID", d.Id())
+				}
+				client := meta.(*ClientConfig).Client
+
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				id, err := strconv.Atoi(idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				cacheSet, err := client.BCacheCacheSet.Get(machine.SystemID, id)
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", cacheSet.ID),
+					"machine": machine.SystemID,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cache_device": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"cache_device", "cache_partition"},
+				Description:  "The block device (ID, name, ID path or path) used as cache device, usually a SSD.",
+			},
+			"cache_partition": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"cache_device", "cache_partition"},
+				Description:  "The partition (ID, name or path) used as cache device.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The machine identifier (system ID, hostname, or FQDN) that owns the cache set.",
+			},
+		},
+	}
+}
+
+func resourceBCacheCacheSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cacheDevice, cachePartition, err := getStorageMemberID(client, machine.SystemID, d, "cache_device", "cache_partition")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := entity.BCacheCacheSetParams{
+		CacheDevice:    cacheDevice,
+		CachePartition: cachePartition,
+	}
+	cacheSet, err := client.BCacheCacheSets.Create(machine.SystemID, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", cacheSet.ID))
+
+	return resourceBCacheCacheSetRead(ctx, d, meta)
+}
+
+func resourceBCacheCacheSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cacheSet, err := client.BCacheCacheSet.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cacheDevice, cachePartition := getStorageMemberTFState(&cacheSet.CacheDevice, d.Get("cache_device").(string), d.Get("cache_partition").(string))
+	tfState := map[string]interface{}{
+		"cache_device":    cacheDevice,
+		"cache_partition": cachePartition,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceBCacheCacheSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cacheDevice, cachePartition, err := getStorageMemberID(client, machine.SystemID, d, "cache_device", "cache_partition")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := entity.BCacheCacheSetParams{
+		CacheDevice:    cacheDevice,
+		CachePartition: cachePartition,
+	}
+	if _, err := client.BCacheCacheSet.Update(machine.SystemID, id, &params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBCacheCacheSetRead(ctx, d, meta)
+}
+
+func resourceBCacheCacheSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.BCacheCacheSet.Delete(machine.SystemID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getStorageMemberID returns the ID of the block device or of the partition given by the arguments.
+func getStorageMemberID(client *client.Client, machineID string, d *schema.ResourceData, blockDeviceKey string, partitionKey string) (string, string, error) {
+	if identifier := d.Get(blockDeviceKey).(string); identifier != "" {
+		blockDevice, err := getBlockDevice(client, machineID, identifier)
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("%v", blockDevice.ID), "", nil
+	}
+	identifier := d.Get(partitionKey).(string)
+	blockDevices, err := client.BlockDevices.Get(machineID)
+	if err != nil {
+		return "", "", err
+	}
+	partition := findBlockDevicePartition(blockDevices, identifier)
+	if partition == nil {
+		return "", "", fmt.Errorf("partition (%s) was not found on machine (%s)", identifier, machineID)
+	}
+	return "", fmt.Sprintf("%v", partition.ID), nil
+}
+
+// getStorageMemberTFState returns the identifiers of the block device or of the partition of the member.
+// The identifier of the current state is kept as long as it matches the member.
+func getStorageMemberTFState(member *entity.BlockDevice, currentBlockDevice string, currentPartition string) (string, string) {
+	devices := []entity.RAIDDevice{{
+		ID:     member.ID,
+		Name:   member.Name,
+		Path:   member.Path,
+		IDPath: member.IDPath,
+		Type:   member.Type,
+	}}
+	if member.Type == "partition" {
+		return "", getStorageMembersTFState(devices, []string{currentPartition})[0]
+	}
+	return getStorageMembersTFState(devices, []string{currentBlockDevice})[0], ""
+}
@@ -0,0 +1,153 @@
+package maas_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-maas/maas"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccMaasBCacheCacheSet(machine string, cacheDevice string) string {
+	return fmt.Sprintf(`
+data "maas_machine" "machine" {
+  hostname = "%s"
+}
+
+resource "maas_block_device" "vdb" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdb"
+  size_gigabytes = 10
+  id_path        = "/dev/vdb"
+}
+
+resource "maas_block_device" "vdc" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdc"
+  size_gigabytes = 10
+  id_path        = "/dev/vdc"
+}
+
+resource "maas_bcache_cache_set" "test" {
+  machine      = data.maas_machine.machine.id
+  cache_device = maas_block_device.%s.name
+}
+`, machine, cacheDevice)
+}
+
+func TestAccResourceMaasBCacheCacheSet_basic(t *testing.T) {
+
+	var cacheSet entity.BCacheCacheSet
+	machine := os.Getenv("TF_ACC_BLOCK_DEVICE_MACHINE")
+
+	checks := []resource.TestCheckFunc{
+		testAccMaasBCacheCacheSetCheckExists("maas_bcache_cache_set.test", &cacheSet),
+		resource.TestCheckResourceAttr("maas_bcache_cache_set.test", "cache_partition", ""),
+		resource.TestCheckResourceAttrPair("maas_bcache_cache_set.test", "machine", "data.maas_machine.machine", "id"),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, []string{"TF_ACC_BLOCK_DEVICE_MACHINE"}) },
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMaasBCacheCacheSetDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaasBCacheCacheSet(machine, "vdb"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_bcache_cache_set.test", "cache_device", "vdb"))...),
+			},
+			// Test update
+			{
+				Config: testAccMaasBCacheCacheSet(machine, "vdc"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_bcache_cache_set.test", "cache_device", "vdc"))...),
+			},
+			// Test import
+			{
+				ResourceName:      "maas_bcache_cache_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["maas_bcache_cache_set.test"]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", "maas_bcache_cache_set.test")
+					}
+
+					if rs.Primary.ID == "" {
+						return "", fmt.Errorf("resource id not set")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["machine"], rs.Primary.Attributes["id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccMaasBCacheCacheSetCheckExists(rn string, cacheSet *entity.BCacheCacheSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		gotCacheSet, err := conn.BCacheCacheSet.Get(rs.Primary.Attributes["machine"], id)
+		if err != nil {
+			return fmt.Errorf("error getting bcache cache set: %s", err)
+		}
+
+		*cacheSet = *gotCacheSet
+
+		return nil
+	}
+}
+
+func testAccCheckMaasBCacheCacheSetDestroy(s *terraform.State) error {
+	// retrieve the connection established in Provider configuration
+	conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+
+	// loop through the resources in state, verifying each maas_bcache_cache_set
+	// is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "maas_bcache_cache_set" {
+			continue
+		}
+
+		// Retrieve our maas_bcache_cache_set by referencing it's state ID for API lookup
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		response, err := conn.BCacheCacheSet.Get(rs.Primary.Attributes["machine"], id)
+		if err == nil {
+			if response != nil && response.ID == id {
+				return fmt.Errorf("MAAS BCache cache set (%s) still exists.", rs.Primary.ID)
+			}
+
+			return nil
+		}
+
+		// If the error is equivalent to 404 not found, the maas_bcache_cache_set is destroyed.
+		// Otherwise return the error
+		if !strings.Contains(err.Error(), "404 Not Found") {
+			return err
+		}
+	}
+
+	return nil
+}
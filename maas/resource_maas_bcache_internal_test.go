@@ -0,0 +1,54 @@
+package maas
+
+import (
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStorageMemberTFState(t *testing.T) {
+	blockDevice := &entity.BlockDevice{ID: 3, Name: "sdb", Path: "/dev/disk/by-dname/sdb", IDPath: "/dev/disk/by-id/wwn-0x5000c500", Type: "physical"}
+	partition := &entity.BlockDevice{ID: 7, Path: "/dev/disk/by-dname/nvme0n1-part2", Type: "partition"}
+
+	tests := []struct {
+		name                string
+		member              *entity.BlockDevice
+		currentBlockDevice  string
+		currentPartition    string
+		expectedBlockDevice string
+		expectedPartition   string
+	}{
+		{
+			name:                "block device ID path",
+			member:              blockDevice,
+			currentBlockDevice:  "/dev/disk/by-id/wwn-0x5000c500",
+			expectedBlockDevice: "/dev/disk/by-id/wwn-0x5000c500",
+		},
+		{
+			name:                "block device changed",
+			member:              blockDevice,
+			currentBlockDevice:  "sdc",
+			expectedBlockDevice: "sdb",
+		},
+		{
+			name:              "partition ID",
+			member:            partition,
+			currentPartition:  "7",
+			expectedPartition: "7",
+		},
+		{
+			name:               "partition imported",
+			member:             partition,
+			currentBlockDevice: "nvme0n1",
+			expectedPartition:  "nvme0n1-part2",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			blockDevice, partition := getStorageMemberTFState(tc.member, tc.currentBlockDevice, tc.currentPartition)
+			assert.Equal(t, tc.expectedBlockDevice, blockDevice)
+			assert.Equal(t, tc.expectedPartition, partition)
+		})
+	}
+}
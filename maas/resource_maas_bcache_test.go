@@ -0,0 +1,170 @@
+package maas_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-maas/maas"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccMaasBCache(machine string, cacheMode string) string {
+	return fmt.Sprintf(`
+data "maas_machine" "machine" {
+  hostname = "%s"
+}
+
+resource "maas_block_device" "vdb" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdb"
+  size_gigabytes = 10
+  id_path        = "/dev/vdb"
+}
+
+resource "maas_block_device" "vdc" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdc"
+  size_gigabytes = 20
+  id_path        = "/dev/vdc"
+}
+
+resource "maas_bcache_cache_set" "test" {
+  machine      = data.maas_machine.machine.id
+  cache_device = maas_block_device.vdb.name
+}
+
+resource "maas_bcache" "test" {
+  machine        = data.maas_machine.machine.id
+  name           = "bcache0"
+  cache_set      = maas_bcache_cache_set.test.id
+  cache_mode     = "%s"
+  backing_device = maas_block_device.vdc.name
+  fs_type        = "ext4"
+  mount_point    = "/srv"
+}
+`, machine, cacheMode)
+}
+
+func TestAccResourceMaasBCache_basic(t *testing.T) {
+
+	var bcache entity.BCache
+	machine := os.Getenv("TF_ACC_BLOCK_DEVICE_MACHINE")
+
+	checks := []resource.TestCheckFunc{
+		testAccMaasBCacheCheckExists("maas_bcache.test", &bcache),
+		resource.TestCheckResourceAttr("maas_bcache.test", "name", "bcache0"),
+		resource.TestCheckResourceAttr("maas_bcache.test", "backing_device", "vdc"),
+		resource.TestCheckResourceAttr("maas_bcache.test", "backing_partition", ""),
+		resource.TestCheckResourceAttr("maas_bcache.test", "fs_type", "ext4"),
+		resource.TestCheckResourceAttr("maas_bcache.test", "mount_point", "/srv"),
+		resource.TestCheckResourceAttrSet("maas_bcache.test", "block_device_id"),
+		resource.TestCheckResourceAttrSet("maas_bcache.test", "uuid"),
+		resource.TestCheckResourceAttrPair("maas_bcache.test", "cache_set", "maas_bcache_cache_set.test", "id"),
+		resource.TestCheckResourceAttrPair("maas_bcache.test", "machine", "data.maas_machine.machine", "id"),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, []string{"TF_ACC_BLOCK_DEVICE_MACHINE"}) },
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMaasBCacheDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaasBCache(machine, "writeback"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_bcache.test", "cache_mode", "writeback"))...),
+			},
+			// Test update
+			{
+				Config: testAccMaasBCache(machine, "writethrough"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_bcache.test", "cache_mode", "writethrough"))...),
+			},
+			// Test import
+			{
+				ResourceName:      "maas_bcache.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["maas_bcache.test"]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", "maas_bcache.test")
+					}
+
+					if rs.Primary.ID == "" {
+						return "", fmt.Errorf("resource id not set")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["machine"], rs.Primary.Attributes["id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccMaasBCacheCheckExists(rn string, bcache *entity.BCache) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		gotBCache, err := conn.BCache.Get(rs.Primary.Attributes["machine"], id)
+		if err != nil {
+			return fmt.Errorf("error getting bcache: %s", err)
+		}
+
+		*bcache = *gotBCache
+
+		return nil
+	}
+}
+
+func testAccCheckMaasBCacheDestroy(s *terraform.State) error {
+	// retrieve the connection established in Provider configuration
+	conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+
+	// loop through the resources in state, verifying each maas_bcache
+	// is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "maas_bcache" {
+			continue
+		}
+
+		// Retrieve our maas_bcache by referencing it's state ID for API lookup
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		response, err := conn.BCache.Get(rs.Primary.Attributes["machine"], id)
+		if err == nil {
+			if response != nil && response.ID == id {
+				return fmt.Errorf("MAAS BCache (%s) still exists.", rs.Primary.ID)
+			}
+
+			return nil
+		}
+
+		// If the error is equivalent to 404 not found, the maas_bcache is destroyed.
+		// Otherwise return the error
+		if !strings.Contains(err.Error(), "404 Not Found") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package maas
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// storageMemberFSTypes are the file system types MAAS gives to the block devices and partitions used by a virtual device.
+var storageMemberFSTypes = []string{"bcache-backing", "bcache-cache", "lvm-pv", "raid", "raid-spare"}
+
 func resourceMaasBlockDevice() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Provides a resource to manage MAAS machines' block devices.",
@@ -95,7 +100,7 @@ func resourceMaasBlockDevice() *schema.Resource {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Computed:    true,
-				Description: "List of partition resources created for the new block device. Parameters defined below. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html). And, it is computed if it's not given.",
+				Description: "List of partition resources created for the new block device. Parameters defined below. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html). And, it is computed if it's not given. The partitions are updated in place when possible, and MAAS can't resize a partition, so a resized partition is recreated along with the following ones. Partitions used by a RAID array, a volume group or a bcache are never deleted, and the changes that would need to delete them fail.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"bootable": {
@@ -104,9 +109,10 @@ func resourceMaasBlockDevice() *schema.Resource {
 							Description: "Boolean value indicating if the partition is set as bootable.",
 						},
 						"fs_type": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The file system type (e.g. `ext4`). If this is not set, the partition is unformatted.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressStorageMemberFSTypeDiff,
+							Description:      "The file system type (e.g. `ext4`). If this is not set, the partition is unformatted. The partitions used by a RAID array, a volume group or a bcache get their file system type from MAAS (e.g. `raid`), so it's left unset for them.",
 						},
 						"label": {
 							Type:        schema.TypeString,
@@ -298,13 +304,18 @@ func findBlockDevice(client *client.Client, machineID string, identifier string)
 		return nil, err
 	}
 	for _, b := range blockDevices {
-		if fmt.Sprintf("%v", b.ID) == identifier || b.Name == identifier || b.IDPath == identifier || b.Path == identifier {
+		if isBlockDeviceIdentifier(&b, identifier) {
 			return &b, nil
 		}
 	}
 	return nil, nil
 }
 
+// isBlockDeviceIdentifier reports whether the block device is given by the identifier (ID, name, ID path or path).
+func isBlockDeviceIdentifier(b *entity.BlockDevice, identifier string) bool {
+	return fmt.Sprintf("%v", b.ID) == identifier || b.Name == identifier || b.IDPath == identifier || b.Path == identifier
+}
+
 func getBlockDevice(client *client.Client, machineID string, identifier string) (*entity.BlockDevice, error) {
 	blockDevice, err := findBlockDevice(client, machineID, identifier)
 	if err != nil {
@@ -383,20 +394,38 @@ func getBlockDevicePartitionsTFState(blockDevice *entity.BlockDevice) []map[stri
 }
 
 func updateBlockDevicePartitions(client *client.Client, d *schema.ResourceData, blockDevice *entity.BlockDevice) error {
+	// Partitions are only rewritten when changed, since they may be used by RAID arrays, volume groups or bcaches
+	if !d.HasChange("partitions") {
+		return nil
+	}
 	p, ok := d.GetOk("partitions")
 	if !ok {
 		return nil
 	}
-	// Remove existing partitions
-	for _, part := range blockDevice.Partitions {
-		if err := client.BlockDevicePartition.Delete(blockDevice.SystemID, blockDevice.ID, part.ID); err != nil {
+	partitions := p.([]interface{})
+	existing := blockDevice.Partitions
+	sort.Slice(existing, func(i, j int) bool {
+		return existing[i].ID < existing[j].ID
+	})
+	recreateFrom, err := getBlockDevicePartitionsRecreatedFrom(existing, partitions)
+	if err != nil {
+		return err
+	}
+
+	// Update the partitions kept in place
+	for i := range existing[:recreateFrom] {
+		if err := updateBlockDevicePartition(client, blockDevice.SystemID, blockDevice.ID, &existing[i], partitions[i].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	// Remove the partitions which are resized or not given anymore, from the last one
+	for i := len(existing) - 1; i >= recreateFrom; i-- {
+		if err := client.BlockDevicePartition.Delete(blockDevice.SystemID, blockDevice.ID, existing[i].ID); err != nil {
 			return err
 		}
-
 	}
-	// Create new partitions given by the user
-	partitions := p.([]interface{})
-	for _, part := range partitions {
+	// Create the new partitions given by the user
+	for _, part := range partitions[recreateFrom:] {
 		partition := part.(map[string]interface{})
 		partitionParams := entity.BlockDevicePartitionParams{
 			Size:     int64(partition["size_gigabytes"].(int)) * 1024 * 1024 * 1024,
@@ -406,23 +435,229 @@ func updateBlockDevicePartitions(client *client.Client, d *schema.ResourceData,
 		if err != nil {
 			return err
 		}
-		tags := partition["tags"].(*schema.Set).List()
-		for _, t := range tags {
-			if _, err := client.BlockDevicePartition.AddTag(blockDevice.SystemID, blockDevice.ID, blockDevicePartition.ID, t.(string)); err != nil {
+		if err := updateBlockDevicePartition(client, blockDevice.SystemID, blockDevice.ID, blockDevicePartition, partition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getBlockDevicePartitionsRecreatedFrom returns the index of the first existing partition (ordered by ID)
+// which must be deleted, since MAAS can't update the size or the bootable flag of a partition, and the
+// following partitions are placed after it. The partitions before this index are updated in place.
+// It returns an error if a partition to delete is used by a RAID array, a volume group or a bcache.
+func getBlockDevicePartitionsRecreatedFrom(existing []entity.BlockDevicePartition, partitions []interface{}) (int, error) {
+	recreateFrom := min(len(existing), len(partitions))
+	for i := range recreateFrom {
+		partition := partitions[i].(map[string]interface{})
+		if int(existing[i].Size/(1024*1024*1024)) != partition["size_gigabytes"].(int) || existing[i].Bootable != partition["bootable"].(bool) {
+			recreateFrom = i
+			break
+		}
+	}
+	for _, part := range existing[recreateFrom:] {
+		if slices.Contains(storageMemberFSTypes, part.FileSystem.FSType) {
+			return 0, fmt.Errorf("partition (%s) is used by a virtual device (%s), so it can't be deleted to apply the partitions changes", part.Path, part.UsedFor)
+		}
+	}
+	return recreateFrom, nil
+}
+
+// suppressStorageMemberFSTypeDiff suppresses the diff of the file system type of a partition used by
+// a virtual device, since MAAS sets it when the partition is added to the virtual device.
+func suppressStorageMemberFSTypeDiff(k, old, new string, d *schema.ResourceData) bool {
+	return new == "" && slices.Contains(storageMemberFSTypes, old)
+}
+
+// updateBlockDevicePartition updates the tags and the file system of an existing partition. The file system of
+// a partition used by a RAID array, a volume group or a bcache is managed with the virtual device, and it's left as is.
+func updateBlockDevicePartition(client *client.Client, machineID string, blockDeviceID int, partition *entity.BlockDevicePartition, config map[string]interface{}) error {
+	tags := convertToStringSlice(config["tags"].(*schema.Set).List())
+	for _, t := range partition.Tags {
+		if !slices.Contains(tags, t) {
+			if _, err := client.BlockDevicePartition.RemoveTag(machineID, blockDeviceID, partition.ID, t); err != nil {
+				return err
+			}
+		}
+	}
+	for _, t := range tags {
+		if !slices.Contains(partition.Tags, t) {
+			if _, err := client.BlockDevicePartition.AddTag(machineID, blockDeviceID, partition.ID, t); err != nil {
+				return err
+			}
+		}
+	}
+
+	current := partition.FileSystem
+	if slices.Contains(storageMemberFSTypes, current.FSType) {
+		return nil
+	}
+	fsType := config["fs_type"].(string)
+	label := config["label"].(string)
+	mountPoint := config["mount_point"].(string)
+	mountOptions := config["mount_options"].(string)
+	if current.FSType == fsType && current.Label == label && current.MountPoint == mountPoint && current.MountOptions == mountOptions {
+		return nil
+	}
+	if current.MountPoint != "" {
+		if _, err := client.BlockDevicePartition.Unmount(machineID, blockDeviceID, partition.ID); err != nil {
+			return err
+		}
+	}
+	if current.FSType != fsType || current.Label != label {
+		if current.FSType != "" {
+			if _, err := client.BlockDevicePartition.Unformat(machineID, blockDeviceID, partition.ID); err != nil {
 				return err
 			}
 		}
-		if fsType := partition["fs_type"].(string); fsType != "" {
-			label := partition["label"].(string)
-			if _, err := client.BlockDevicePartition.Format(blockDevice.SystemID, blockDevice.ID, blockDevicePartition.ID, fsType, label); err != nil {
+		if fsType != "" {
+			if _, err := client.BlockDevicePartition.Format(machineID, blockDeviceID, partition.ID, fsType, label); err != nil {
 				return err
 			}
-			if mountPoint := partition["mount_point"].(string); mountPoint != "" {
-				mountOptions := partition["mount_options"].(string)
-				if _, err := client.BlockDevicePartition.Mount(blockDevice.SystemID, blockDevice.ID, blockDevicePartition.ID, mountPoint, mountOptions); err != nil {
-					return err
+		}
+	}
+	if fsType != "" && mountPoint != "" {
+		if _, err := client.BlockDevicePartition.Mount(machineID, blockDeviceID, partition.ID, mountPoint, mountOptions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getStorageMemberIDs returns the IDs of the block devices and partitions given by the storage group members arguments.
+func getStorageMemberIDs(client *client.Client, machineID string, d *schema.ResourceData, blockDeviceKeys []string, partitionKeys []string) (map[string][]string, error) {
+	members := map[string][]string{}
+	for _, k := range blockDeviceKeys {
+		members[k] = []string{}
+		for _, identifier := range convertToStringSlice(d.Get(k).(*schema.Set).List()) {
+			blockDevice, err := getBlockDevice(client, machineID, identifier)
+			if err != nil {
+				return nil, err
+			}
+			members[k] = append(members[k], fmt.Sprintf("%v", blockDevice.ID))
+		}
+	}
+	var blockDevices []entity.BlockDevice
+	for _, k := range partitionKeys {
+		members[k] = []string{}
+		for _, identifier := range convertToStringSlice(d.Get(k).(*schema.Set).List()) {
+			if blockDevices == nil {
+				var err error
+				if blockDevices, err = client.BlockDevices.Get(machineID); err != nil {
+					return nil, err
 				}
 			}
+			partition := findBlockDevicePartition(blockDevices, identifier)
+			if partition == nil {
+				return nil, fmt.Errorf("partition (%s) was not found on machine (%s)", identifier, machineID)
+			}
+			members[k] = append(members[k], fmt.Sprintf("%v", partition.ID))
+		}
+	}
+	return members, nil
+}
+
+// findBlockDevicePartition returns the partition given by its ID, name or path.
+func findBlockDevicePartition(blockDevices []entity.BlockDevice, identifier string) *entity.BlockDevicePartition {
+	for _, blockDevice := range blockDevices {
+		for _, partition := range blockDevice.Partitions {
+			if fmt.Sprintf("%v", partition.ID) == identifier || partition.Path == identifier || path.Base(partition.Path) == identifier {
+				return &partition
+			}
+		}
+	}
+	return nil
+}
+
+// splitStorageMembers returns the block devices and the partitions of the members of a storage group (RAID array, volume group, etc.).
+func splitStorageMembers(devices []entity.RAIDDevice) ([]entity.RAIDDevice, []entity.RAIDDevice) {
+	blockDevices := []entity.RAIDDevice{}
+	partitions := []entity.RAIDDevice{}
+	for _, device := range devices {
+		if device.Type == "partition" {
+			partitions = append(partitions, device)
+		} else {
+			blockDevices = append(blockDevices, device)
+		}
+	}
+	return blockDevices, partitions
+}
+
+// getStorageMembersTFState returns the identifiers of the members of a storage group. The identifiers of the
+// current state are kept as long as they match a member, and the other members are given by name.
+func getStorageMembersTFState(devices []entity.RAIDDevice, current []string) []string {
+	members := []string{}
+	for _, device := range devices {
+		member := device.Name
+		if member == "" {
+			member = path.Base(device.Path)
+		}
+		for _, identifier := range current {
+			if identifier == "" {
+				continue
+			}
+			if fmt.Sprintf("%v", device.ID) == identifier || device.Name == identifier || device.IDPath == identifier || device.Path == identifier || path.Base(device.Path) == identifier {
+				member = identifier
+				break
+			}
+		}
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// getStorageMemberChanges returns the IDs of the members to add to and to remove from a storage group.
+func getStorageMemberChanges(devices []entity.RAIDDevice, desired []string) ([]string, []string) {
+	current := make([]string, len(devices))
+	for i, device := range devices {
+		current[i] = fmt.Sprintf("%v", device.ID)
+	}
+	add := []string{}
+	for _, id := range desired {
+		if !slices.Contains(current, id) {
+			add = append(add, id)
+		}
+	}
+	remove := []string{}
+	for _, id := range current {
+		if !slices.Contains(desired, id) {
+			remove = append(remove, id)
+		}
+	}
+	return add, remove
+}
+
+// updateBlockDeviceFilesystem formats and mounts a virtual block device (RAID array, logical volume, etc.) as configured.
+func updateBlockDeviceFilesystem(client *client.Client, machineID string, blockDevice *entity.BlockDevice, d *schema.ResourceData) error {
+	fsType := d.Get("fs_type").(string)
+	mountPoint := d.Get("mount_point").(string)
+	mountOptions := d.Get("mount_options").(string)
+	current := blockDevice.Filesystem
+	if current.FSType == fsType && current.MountPoint == mountPoint && current.MountOptions == mountOptions {
+		return nil
+	}
+
+	if current.MountPoint != "" {
+		if _, err := client.BlockDevice.Unmount(machineID, blockDevice.ID); err != nil {
+			return err
+		}
+	}
+	if current.FSType != fsType {
+		if current.FSType != "" {
+			if _, err := client.BlockDevice.Unformat(machineID, blockDevice.ID); err != nil {
+				return err
+			}
+		}
+		if fsType != "" {
+			if _, err := client.BlockDevice.Format(machineID, blockDevice.ID, fsType); err != nil {
+				return err
+			}
+		}
+	}
+	if fsType != "" && mountPoint != "" {
+		if _, err := client.BlockDevice.Mount(machineID, blockDevice.ID, mountPoint, mountOptions); err != nil {
+			return err
 		}
 	}
 	return nil
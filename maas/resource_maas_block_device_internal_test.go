@@ -0,0 +1,243 @@
+package maas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStorageMembersTFState(t *testing.T) {
+	devices := []entity.RAIDDevice{
+		{ID: 1, Name: "nvme0n1", Path: "/dev/disk/by-dname/nvme0n1", IDPath: "/dev/disk/by-id/nvme-0"},
+		{ID: 2, Name: "nvme1n1", Path: "/dev/disk/by-dname/nvme1n1", IDPath: "/dev/disk/by-id/nvme-1"},
+		{ID: 3, Path: "/dev/disk/by-dname/sda-part2", Type: "partition"},
+	}
+
+	tests := []struct {
+		name     string
+		current  []string
+		expected []string
+	}{
+		{
+			name:     "names",
+			current:  []string{"nvme0n1", "nvme1n1", "sda-part2"},
+			expected: []string{"nvme0n1", "nvme1n1", "sda-part2"},
+		},
+		{
+			name:     "other identifiers",
+			current:  []string{"1", "/dev/disk/by-id/nvme-1", "/dev/disk/by-dname/sda-part2"},
+			expected: []string{"/dev/disk/by-dname/sda-part2", "/dev/disk/by-id/nvme-1", "1"},
+		},
+		{
+			name:     "imported",
+			current:  []string{},
+			expected: []string{"nvme0n1", "nvme1n1", "sda-part2"},
+		},
+		{
+			name:     "removed outside terraform",
+			current:  []string{"nvme0n1", "nvme2n1", "4"},
+			expected: []string{"nvme0n1", "nvme1n1", "sda-part2"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getStorageMembersTFState(devices, tc.current))
+		})
+	}
+}
+
+func TestGetStorageMemberChanges(t *testing.T) {
+	devices := []entity.RAIDDevice{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name           string
+		desired        []string
+		expectedAdd    []string
+		expectedRemove []string
+	}{
+		{
+			name:           "unchanged",
+			desired:        []string{"3", "1", "2"},
+			expectedAdd:    []string{},
+			expectedRemove: []string{},
+		},
+		{
+			name:           "replaced",
+			desired:        []string{"1", "2", "4"},
+			expectedAdd:    []string{"4"},
+			expectedRemove: []string{"3"},
+		},
+		{
+			name:           "empty",
+			desired:        []string{},
+			expectedAdd:    []string{},
+			expectedRemove: []string{"1", "2", "3"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			add, remove := getStorageMemberChanges(devices, tc.desired)
+			assert.Equal(t, tc.expectedAdd, add)
+			assert.Equal(t, tc.expectedRemove, remove)
+		})
+	}
+}
+
+func TestFindBlockDevicePartition(t *testing.T) {
+	blockDevices := []entity.BlockDevice{
+		{ID: 1, Partitions: []entity.BlockDevicePartition{{ID: 10, Path: "/dev/disk/by-dname/sda-part1"}}},
+		{ID: 2, Partitions: []entity.BlockDevicePartition{{ID: 20, Path: "/dev/disk/by-dname/sdb-part1"}}},
+	}
+
+	for _, identifier := range []string{"20", "sdb-part1", "/dev/disk/by-dname/sdb-part1"} {
+		partition := findBlockDevicePartition(blockDevices, identifier)
+		if assert.NotNil(t, partition, identifier) {
+			assert.Equal(t, 20, partition.ID)
+		}
+	}
+	assert.Nil(t, findBlockDevicePartition(blockDevices, "sdc-part1"))
+}
+
+func TestGetBlockDevicePartitionsRecreatedFrom(t *testing.T) {
+	existing := []entity.BlockDevicePartition{
+		{ID: 40, Path: "/dev/disk/by-dname/sda-part1", Size: 1 * 1024 * 1024 * 1024, Bootable: true, FileSystem: entity.PartitionFileSystem{FSType: "fat32"}},
+		{ID: 41, Path: "/dev/disk/by-dname/sda-part2", Size: 20 * 1024 * 1024 * 1024, FileSystem: entity.PartitionFileSystem{FSType: "ext4"}},
+		{ID: 42, Path: "/dev/disk/by-dname/sda-part3", Size: 100 * 1024 * 1024 * 1024, UsedFor: "Active raid-1 device for md0", FileSystem: entity.PartitionFileSystem{FSType: "raid"}},
+	}
+	partition := func(size int, bootable bool) map[string]interface{} {
+		return map[string]interface{}{"size_gigabytes": size, "bootable": bootable}
+	}
+
+	tests := []struct {
+		name       string
+		existing   []entity.BlockDevicePartition
+		partitions []interface{}
+		expected   int
+		err        string
+	}{
+		{
+			name:       "unchanged sizes",
+			existing:   existing,
+			partitions: []interface{}{partition(1, true), partition(20, false), partition(100, false)},
+			expected:   3,
+		},
+		{
+			name:       "new partition",
+			existing:   existing,
+			partitions: []interface{}{partition(1, true), partition(20, false), partition(100, false), partition(50, false)},
+			expected:   3,
+		},
+		{
+			name:       "resized partition",
+			existing:   existing[:2],
+			partitions: []interface{}{partition(1, true), partition(30, false)},
+			expected:   1,
+		},
+		{
+			name:       "removed partition",
+			existing:   existing[:2],
+			partitions: []interface{}{partition(1, true)},
+			expected:   1,
+		},
+		{
+			name:       "bootable flag changed",
+			existing:   existing,
+			partitions: []interface{}{partition(1, false), partition(20, false), partition(100, false)},
+			err:        "partition (/dev/disk/by-dname/sda-part3) is used by a virtual device (Active raid-1 device for md0), so it can't be deleted to apply the partitions changes",
+		},
+		{
+			name:       "resized partition before a RAID member",
+			existing:   existing,
+			partitions: []interface{}{partition(1, true), partition(30, false), partition(100, false)},
+			err:        "partition (/dev/disk/by-dname/sda-part3) is used by a virtual device (Active raid-1 device for md0), so it can't be deleted to apply the partitions changes",
+		},
+		{
+			name:       "removed RAID member",
+			existing:   existing,
+			partitions: []interface{}{partition(1, true), partition(20, false)},
+			err:        "partition (/dev/disk/by-dname/sda-part3) is used by a virtual device (Active raid-1 device for md0), so it can't be deleted to apply the partitions changes",
+		},
+		{
+			name:       "resized RAID member",
+			existing:   existing,
+			partitions: []interface{}{partition(1, true), partition(20, false), partition(200, false)},
+			err:        "partition (/dev/disk/by-dname/sda-part3) is used by a virtual device (Active raid-1 device for md0), so it can't be deleted to apply the partitions changes",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recreateFrom, err := getBlockDevicePartitionsRecreatedFrom(tc.existing, tc.partitions)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, recreateFrom)
+		})
+	}
+}
+
+func TestBlockDevicePartitionsStorageMemberDiff(t *testing.T) {
+	state := map[string]string{
+		"id":                          "10",
+		"machine":                     "abc123",
+		"name":                        "sda",
+		"id_path":                     "/dev/disk/by-id/wwn-0x1",
+		"model":                       "",
+		"serial":                      "",
+		"block_size":                  "512",
+		"size_gigabytes":              "120",
+		"tags.#":                      "0",
+		"partitions.#":                "2",
+		"partitions.0.size_gigabytes": "20",
+		"partitions.0.bootable":       "false",
+		"partitions.0.fs_type":        "ext4",
+		"partitions.0.mount_point":    "/",
+		"partitions.0.tags.#":         "0",
+		"partitions.0.path":           "/dev/disk/by-dname/sda-part1",
+		"partitions.1.size_gigabytes": "100",
+		"partitions.1.bootable":       "false",
+		"partitions.1.fs_type":        "raid",
+		"partitions.1.tags.#":         "0",
+		"partitions.1.path":           "/dev/disk/by-dname/sda-part2",
+	}
+
+	tests := []struct {
+		name     string
+		fsType   string
+		expected string
+	}{
+		{
+			name: "RAID member partition",
+		},
+		{
+			name:     "formatted partition",
+			fsType:   "ext4",
+			expected: "ext4",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"machine":        "abc123",
+				"name":           "sda",
+				"id_path":        "/dev/disk/by-id/wwn-0x1",
+				"size_gigabytes": 120,
+				"partitions": []interface{}{
+					map[string]interface{}{"size_gigabytes": 20, "fs_type": "ext4", "mount_point": "/"},
+					map[string]interface{}{"size_gigabytes": 100, "fs_type": tc.fsType},
+				},
+			})
+			diff, err := resourceMaasBlockDevice().Diff(context.Background(), &terraform.InstanceState{ID: "10", Attributes: state}, config, nil)
+			assert.NoError(t, err)
+			if tc.expected == "" {
+				assert.Nil(t, diff)
+				return
+			}
+			assert.Equal(t, tc.expected, diff.Attributes["partitions.1.fs_type"].New)
+		})
+	}
+}
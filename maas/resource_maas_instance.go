@@ -17,6 +17,7 @@ func resourceMaasInstance() *schema.Resource {
 		Description:   "Provides a resource to deploy and release machines already configured in MAAS, based on the specified parameters. If no parameters are given, a random machine will be allocated and deployed using the defaults.\n\n**NOTE:** The MAAS provider currently provides both standalone resources and in-line resources for network interfaces. You cannot use in-line network interfaces in conjunction with any standalone network interfaces resources. Doing so will cause conflicts and will overwrite network configs.",
 		CreateContext: resourceInstanceCreate,
 		ReadContext:   resourceInstanceRead,
+		UpdateContext: resourceInstanceUpdate,
 		DeleteContext: resourceInstanceDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -192,6 +193,14 @@ func resourceMaasInstance() *schema.Resource {
 					},
 				},
 			},
+			"owner_data": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "A map of owner data (workload annotations) set on the MAAS machine after it is allocated. The owner data is cleared when the machine is released.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"pool": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -230,6 +239,11 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, meta in
 	// Save system id
 	d.SetId(machine.SystemID)
 
+	// Set owner data
+	if err := setMachineWorkloadAnnotations(client, machine.SystemID, machine.WorkloadAnnotations, d.Get("owner_data").(map[string]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Configure network interfaces
 	err = configureInstanceNetworkInterfaces(client, d, machine)
 	if err != nil {
@@ -274,6 +288,7 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta inte
 		"cpu_count":    machine.CPUCount,
 		"memory":       machine.Memory,
 		"ip_addresses": ipAddresses,
+		"owner_data":   machine.WorkloadAnnotations,
 	}
 	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
@@ -282,11 +297,37 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	// Get MAAS machine
+	machine, err := client.Machine.Get(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Update owner data
+	if err := setMachineWorkloadAnnotations(client, machine.SystemID, machine.WorkloadAnnotations, d.Get("owner_data").(map[string]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceInstanceRead(ctx, d, meta)
+}
+
 func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ClientConfig).Client
 
+	// Clear owner data
+	machine, err := client.Machine.Get(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setMachineWorkloadAnnotations(client, machine.SystemID, machine.WorkloadAnnotations, map[string]interface{}{}); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Release MAAS machine
-	err := client.Machines.Release([]string{d.Id()}, "Released by Terraform")
+	err = client.Machines.Release([]string{d.Id()}, "Released by Terraform")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,269 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMaasLogicalVolume() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS machines' LVM logical volumes.",
+		CreateContext: resourceLogicalVolumeCreate,
+		ReadContext:   resourceLogicalVolumeRead,
+		UpdateContext: resourceLogicalVolumeUpdate,
+		DeleteContext: resourceLogicalVolumeDelete,
+		CustomizeDiff: resourceLogicalVolumeCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:LOGICAL_VOLUME", d.Id())
+				}
+				client := meta.(*ClientConfig).Client
+
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				blockDevice, err := getBlockDevice(client, machine.SystemID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				volumeGroups, err := client.VolumeGroups.Get(machine.SystemID)
+				if err != nil {
+					return nil, err
+				}
+				volumeGroup := findLogicalVolumeGroup(volumeGroups, blockDevice.ID)
+				if volumeGroup == nil {
+					return nil, fmt.Errorf("block device (%s) is not a logical volume", idParts[1])
+				}
+				tfState := map[string]interface{}{
+					"id":           fmt.Sprintf("%v", blockDevice.ID),
+					"machine":      machine.SystemID,
+					"volume_group": volumeGroup.Name,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fs_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file system type (e.g. `ext4`) of the logical volume. If this is not set, the logical volume is unformatted.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The machine identifier (system ID, hostname, or FQDN) that owns the logical volume.",
+			},
+			"mount_options": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"mount_point"},
+				Description:  "The options used for the logical volume mount.",
+			},
+			"mount_point": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"fs_type"},
+				Description:  "The mount point used. If this is not set, the logical volume is not mounted. This is used only if the logical volume is formatted.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The logical volume name, without the volume group name prefix added by MAAS to the block device name.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the logical volume block device.",
+			},
+			"size_gigabytes": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The size of the logical volume (given in GB). The logical volume is grown in place, and it is recreated if it's shrunk.",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The logical volume UUID.",
+			},
+			"volume_group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The volume group (ID or name) of the logical volume.",
+			},
+		},
+	}
+}
+
+func resourceLogicalVolumeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// LVM logical volumes can only be grown in place
+	if d.Id() != "" && d.HasChange("size_gigabytes") {
+		oldSize, newSize := d.GetChange("size_gigabytes")
+		if newSize.(int) < oldSize.(int) {
+			return d.ForceNew("size_gigabytes")
+		}
+	}
+	return nil
+}
+
+func resourceLogicalVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroup, err := getVolumeGroup(client, machine.SystemID, d.Get("volume_group").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := url.Values{}
+	params.Set("name", d.Get("name").(string))
+	params.Set("size", fmt.Sprintf("%v", int64(d.Get("size_gigabytes").(int))*1024*1024*1024))
+	blockDevice, err := createLogicalVolume(client, machine.SystemID, volumeGroup.ID, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", blockDevice.ID))
+
+	if err := updateBlockDeviceFilesystem(client, machine.SystemID, blockDevice, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLogicalVolumeRead(ctx, d, meta)
+}
+
+func resourceLogicalVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroup, err := getVolumeGroup(client, machine.SystemID, d.Get("volume_group").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockDevice, err := client.BlockDevice.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfState := map[string]interface{}{
+		"name":           strings.TrimPrefix(blockDevice.Name, volumeGroup.Name+"-"),
+		"uuid":           blockDevice.UUID,
+		"path":           blockDevice.Path,
+		"size_gigabytes": int(blockDevice.Size / (1024 * 1024 * 1024)),
+		"fs_type":        blockDevice.Filesystem.FSType,
+		"mount_point":    blockDevice.Filesystem.MountPoint,
+		"mount_options":  blockDevice.Filesystem.MountOptions,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceLogicalVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockDevice, err := client.BlockDevice.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("size_gigabytes") {
+		params := entity.BlockDeviceParams{Size: int64(d.Get("size_gigabytes").(int)) * 1024 * 1024 * 1024}
+		if blockDevice, err = client.BlockDevice.Update(machine.SystemID, id, &params); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := updateBlockDeviceFilesystem(client, machine.SystemID, blockDevice, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLogicalVolumeRead(ctx, d, meta)
+}
+
+func resourceLogicalVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroup, err := getVolumeGroup(client, machine.SystemID, d.Get("volume_group").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroupClient, err := getVolumeGroupAPIClient(client, machine.SystemID, fmt.Sprintf("%v", volumeGroup.ID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := url.Values{}
+	params.Set("id", d.Id())
+	if err := volumeGroupClient.Post("delete_logical_volume", params, func(data []byte) error { return nil }); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// createLogicalVolume creates a logical volume through the API client, since client.VolumeGroup
+// does not send the size of the logical volume.
+func createLogicalVolume(client *client.Client, machineID string, volumeGroupID int, params url.Values) (*entity.BlockDevice, error) {
+	volumeGroupClient, err := getVolumeGroupAPIClient(client, machineID, fmt.Sprintf("%v", volumeGroupID))
+	if err != nil {
+		return nil, err
+	}
+	blockDevice := new(entity.BlockDevice)
+	err = volumeGroupClient.Post("create_logical_volume", params, func(data []byte) error {
+		return json.Unmarshal(data, blockDevice)
+	})
+	return blockDevice, err
+}
+
+// findLogicalVolumeGroup returns the volume group of the logical volume given by its block device ID.
+func findLogicalVolumeGroup(volumeGroups []entity.VolumeGroup, id int) *entity.VolumeGroup {
+	for _, volumeGroup := range volumeGroups {
+		for _, logicalVolume := range volumeGroup.LogicalVolumes {
+			if logicalVolume.ID == id {
+				return &volumeGroup
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package maas_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-maas/maas"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccMaasLogicalVolume(machine string, sizeGigabytes int) string {
+	return fmt.Sprintf(`
+data "maas_machine" "machine" {
+  hostname = "%s"
+}
+
+resource "maas_block_device" "vdb" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdb"
+  size_gigabytes = 20
+  id_path        = "/dev/vdb"
+}
+
+resource "maas_volume_group" "test" {
+  machine       = data.maas_machine.machine.id
+  name          = "tf-vg-lv"
+  block_devices = [maas_block_device.vdb.name]
+}
+
+resource "maas_logical_volume" "test" {
+  machine        = data.maas_machine.machine.id
+  volume_group   = maas_volume_group.test.name
+  name           = "tf-lv"
+  size_gigabytes = %d
+  fs_type        = "ext4"
+  mount_point    = "/srv"
+}
+`, machine, sizeGigabytes)
+}
+
+func TestAccResourceMaasLogicalVolume_basic(t *testing.T) {
+
+	var logicalVolume entity.BlockDevice
+	machine := os.Getenv("TF_ACC_BLOCK_DEVICE_MACHINE")
+
+	checks := []resource.TestCheckFunc{
+		testAccMaasLogicalVolumeCheckExists("maas_logical_volume.test", &logicalVolume),
+		resource.TestCheckResourceAttr("maas_logical_volume.test", "name", "tf-lv"),
+		resource.TestCheckResourceAttr("maas_logical_volume.test", "fs_type", "ext4"),
+		resource.TestCheckResourceAttr("maas_logical_volume.test", "mount_point", "/srv"),
+		resource.TestCheckResourceAttrSet("maas_logical_volume.test", "path"),
+		resource.TestCheckResourceAttrSet("maas_logical_volume.test", "uuid"),
+		resource.TestCheckResourceAttrPair("maas_logical_volume.test", "volume_group", "maas_volume_group.test", "name"),
+		resource.TestCheckResourceAttrPair("maas_logical_volume.test", "machine", "data.maas_machine.machine", "id"),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, []string{"TF_ACC_BLOCK_DEVICE_MACHINE"}) },
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMaasLogicalVolumeDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaasLogicalVolume(machine, 5),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_logical_volume.test", "size_gigabytes", "5"))...),
+			},
+			// Test update
+			{
+				Config: testAccMaasLogicalVolume(machine, 10),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_logical_volume.test", "size_gigabytes", "10"))...),
+			},
+			// Test import
+			{
+				ResourceName:      "maas_logical_volume.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["maas_logical_volume.test"]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", "maas_logical_volume.test")
+					}
+
+					if rs.Primary.ID == "" {
+						return "", fmt.Errorf("resource id not set")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["machine"], rs.Primary.Attributes["id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccMaasLogicalVolumeCheckExists(rn string, logicalVolume *entity.BlockDevice) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		gotLogicalVolume, err := conn.BlockDevice.Get(rs.Primary.Attributes["machine"], id)
+		if err != nil {
+			return fmt.Errorf("error getting logical volume: %s", err)
+		}
+
+		*logicalVolume = *gotLogicalVolume
+
+		return nil
+	}
+}
+
+func testAccCheckMaasLogicalVolumeDestroy(s *terraform.State) error {
+	// retrieve the connection established in Provider configuration
+	conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+
+	// loop through the resources in state, verifying each maas_logical_volume
+	// is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "maas_logical_volume" {
+			continue
+		}
+
+		// Retrieve our maas_logical_volume by referencing it's state ID for API lookup
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		response, err := conn.BlockDevice.Get(rs.Primary.Attributes["machine"], id)
+		if err == nil {
+			if response != nil && response.ID == id {
+				return fmt.Errorf("MAAS Logical volume (%s) still exists.", rs.Primary.ID)
+			}
+
+			return nil
+		}
+
+		// If the error is equivalent to 404 not found, the maas_logical_volume is destroyed.
+		// Otherwise return the error
+		if !strings.Contains(err.Error(), "404 Not Found") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/canonical/gomaasclient/client"
 	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,13 +20,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// machineCommissionOptions are the commissioning options sent to MAAS along with the script parameters.
+var machineCommissionOptions = []string{"commissioning_scripts", "enable_ssh", "skip_bmc_config", "skip_networking", "skip_storage", "testing_scripts"}
+
 func resourceMaasMachine() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Description:   "Provides a resource to manage MAAS machines.",
 		CreateContext: resourceMachineCreate,
 		ReadContext:   resourceMachineRead,
 		UpdateContext: resourceMachineUpdate,
 		DeleteContext: resourceMachineDelete,
+		CustomizeDiff: resourceMachineCustomizeDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("power_parameters"), cty.GetAttrPath("power_parameters_wo")),
+		},
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -68,18 +79,157 @@ func resourceMaasMachine() *schema.Resource {
 				Default:     "amd64/generic",
 				Description: "The architecture type of the machine. Defaults to `amd64/generic`.",
 			},
+			"block_devices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of block devices of the machine.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The block device ID.",
+						},
+						"id_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The block device path that doesn't change depending on the boot order or kernel version.",
+						},
+						"model": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The block device model.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The block device name.",
+						},
+						"numa_node": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The NUMA node index of the block device.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The block device path.",
+						},
+						"serial": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The block device serial number.",
+						},
+						"size_gigabytes": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The block device size (in GB).",
+						},
+						"tags": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "A set of tag names assigned to the block device.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The block device type (e.g. `physical`).",
+						},
+					},
+				},
+			},
+			"commission_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value that triggers the machine to be re-commissioned in place, using the current commissioning options, whenever it changes (e.g. after a firmware update).",
+			},
+			"commissioning_scripts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A list of commissioning script names and tags to be run. By default, all custom commissioning scripts are run. Built-in commissioning scripts always run.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"cpu_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of CPU cores of the machine.",
+			},
+			"cpu_speed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The CPU speed of the machine (in MHz).",
+			},
+			"current_power_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current power state of the machine (e.g. `on`, `off`, `unknown`), whether or not it's managed with `power_state`.",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the machine is protected from being destroyed. When set to `true`, destroying the machine fails, and this must be set to `false` (and applied) first. Defaults to `false`.",
+			},
+			"desired_state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "unmanaged",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"ready", "broken", "deployed", "locked", "rescue", "unmanaged"}, false)),
+				Description:      "The desired lifecycle state of the machine. Supported values are: `ready`, `broken`, `deployed` (only for deployed machines, e.g. to exit the rescue mode of a deployed machine), `locked` (only for deployed machines), `rescue`, `unmanaged`. A machine exits the rescue mode in the status it had before entering it, so `ready` fails for a machine that was deployed, and `deployed` must be used instead. When set to a value other than `unmanaged`, the transitions are computed from the current machine status, and any status change done outside of Terraform is reported as drift. Defaults to `unmanaged`.",
+			},
+			"desired_state_comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The comment recorded in MAAS when the machine state is changed (e.g. the reason why the machine is marked broken).",
+			},
 			"domain": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
 				Description: "The domain of the machine. This is computed if it's not set.",
 			},
+			"enable_ssh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if SSH is enabled in the commissioning environment, allowing the user to log in to the machine. Defaults to `false`.",
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the machine is deleted on destroy even if it's not in the `New`, `Ready` or `Broken` status (e.g. if it's deployed). By default, destroying such a machine fails, unless `release_on_destroy` is set. Defaults to `false`.",
+			},
 			"hostname": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
 				Description: "The machine hostname. This is computed if it's not set.",
 			},
+			"hardware_info": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map with the hardware details of the machine gathered during commissioning (e.g. `system_vendor`, `system_product`, `system_serial`, `mainboard_firmware_version`).",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"memory": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The RAM memory size of the machine (in MB).",
+			},
+			"ignore_power_parameters_defaults": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Boolean value indicating if the power parameters added by the MAAS server (e.g. default values) are ignored when detecting drift. Only the keys given in `power_parameters` are then compared. Defaults to `true`.",
+			},
 			"min_hwe_kernel": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -94,6 +244,44 @@ func resourceMaasMachine() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"numa_nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of NUMA nodes of the machine.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cores": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The list of CPU core indexes of the NUMA node.",
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+						"index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The NUMA node index.",
+						},
+						"memory": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The RAM memory size of the NUMA node (in MB).",
+						},
+					},
+				},
+			},
+			"override_failed_testing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the failed testing status of the machine is overridden, so that the machine can be used. Defaults to `false`.",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user owning the machine, if it's allocated.",
+			},
 			"pool": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -101,31 +289,25 @@ func resourceMaasMachine() *schema.Resource {
 				Description: "The resource pool of the machine. This is computed if it's not set.",
 			},
 			"power_parameters": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Sensitive:    true,
-				ValidateFunc: validation.StringIsJSON,
-				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-					oldMap, err := structure.ExpandJsonFromString(oldValue)
-					if err != nil {
-						return false
-					}
-					newMap, err := structure.ExpandJsonFromString(newValue)
-					if err != nil {
-						return false
-					}
-					return reflect.DeepEqual(oldMap, newMap)
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Sensitive:        true,
+				ExactlyOneOf:     getMachinePowerSources(),
+				RequiredWith:     []string{"power_type"},
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: powerParametersDiffSuppressFunc,
 				StateFunc: func(v interface{}) string {
 					json, _ := structure.NormalizeJsonString(v)
 					return json
 				},
-				Description: "Serialized JSON string containing the parameters specific to the `power_type`. See [Power types](https://maas.io/docs/api#power-types) section for a list of the available power parameters for each power type.",
+				Description: "Serialized JSON string containing the parameters specific to the `power_type`. See [Power types](https://maas.io/docs/api#power-types) section for a list of the available power parameters for each power type. This is stored in the Terraform state, consider using `power_parameters_wo` instead. Conflicts with `power_parameters_wo` and the typed power type blocks (e.g. `ipmi`). This is left empty if a typed power type block is used, since the power parameters are stored in the block.",
 			},
 			"power_type": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "A power management type (e.g. `ipmi`).",
+				Optional:    true,
+				Computed:    true,
+				Description: "A power management type (e.g. `ipmi`). Required if `power_parameters` is set. This is computed if a typed power type block (e.g. `ipmi`) is used.",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(
 					[]string{
 						"amt", "apc", "dli", "eaton", "hmc", "ipmi", "manual", "moonshot",
@@ -134,11 +316,114 @@ func resourceMaasMachine() *schema.Resource {
 					},
 					false)),
 			},
+			"power_parameters_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: getMachinePowerSources(),
+				RequiredWith: []string{"power_type", "power_parameters_wo_version"},
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "Write-only variant of `power_parameters`. This is never stored in the Terraform state, and it can be set from an ephemeral value. Requires Terraform 1.11 or later. The power parameters are only sent to MAAS when the machine is created, or when `power_parameters_wo_version` changes, and no drift is detected for them.",
+			},
+			"power_parameters_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"power_parameters_wo"},
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "An arbitrary version number of the `power_parameters_wo` value. Changing it updates the machine power parameters with the current `power_parameters_wo` value (e.g. after a BMC password rotation).",
+			},
+			"power_state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "unmanaged",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"on", "off", "unmanaged"}, false)),
+				Description:      "The desired power state of the machine. Supported values are: `on`, `off`, `unmanaged`. When set to `on` or `off`, the machine is powered on or off using its `power_type` and `power_parameters`, and any power state change done outside of Terraform is reported as drift. Defaults to `unmanaged`.",
+			},
 			"pxe_mac_address": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The MAC address of the machine's PXE boot NIC.",
 			},
+			"release_on_destroy": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Nested argument with the options used to release the machine on destroy, if it's allocated or deployed. The machine is deleted after it's released. If it's not set, allocated or deployed machines are not released, and destroying them fails unless `force_destroy` is set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"comment": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "Released by Terraform",
+							Description: "The comment recorded in MAAS when the machine is released. Defaults to `Released by Terraform`.",
+						},
+						"erase": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Boolean value indicating if the machine disks are erased when the machine is released. Defaults to `false`.",
+						},
+						"quick_erase": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Boolean value indicating if only the beginning and the end of each disk are wiped when erasing. Only used if `erase` is `true`. Defaults to `false`.",
+						},
+						"secure_erase": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Boolean value indicating if the disks are erased using the secure erase feature of the disk, if supported. Only used if `erase` is `true`. Defaults to `false`.",
+						},
+					},
+				},
+			},
+			"script_parameters": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				ValidateDiagFunc: isMachineScriptParameters,
+				Description:      "A map of parameters passed to the commissioning and testing scripts. The keys are given in the `<script name>_<parameter name>` format (e.g. `fio_storage`), and cannot be the name of a commissioning option (e.g. `enable_ssh`).",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"skip_bmc_config": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the BMC configuration commissioning scripts are skipped. Defaults to `false`.",
+			},
+			"skip_networking": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the existing network interfaces configuration is kept when commissioning. Defaults to `false`.",
+			},
+			"skip_storage": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the existing storage configuration is kept when commissioning. Defaults to `false`.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The machine status (e.g. `Ready`).",
+			},
+			"storage": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total storage size of the machine (in MB).",
+			},
+			"testing_scripts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A list of testing script names and tags to be run after commissioning. By default, the scripts tagged `commissioning` are run. Set to `[\"none\"]` to skip testing.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"zone": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -148,8 +433,17 @@ func resourceMaasMachine() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 	}
+
+	// Typed power configuration blocks, one for each supported power type
+	for k, v := range getMachinePowerSchemas() {
+		resource.Schema[k] = v
+	}
+
+	return resource
 }
 
 func resourceMachineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -160,7 +454,13 @@ func resourceMachineCreate(ctx context.Context, d *schema.ResourceData, meta int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	machine, err := client.Machines.Create(getMachineParams(d), powerParams)
+	commissionParams, err := getMachineCommissionParams(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machineParams := getMachineParams(d)
+	machineParams.Commission = false
+	machine, err := client.Machines.Create(machineParams, powerParams)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -168,8 +468,17 @@ func resourceMachineCreate(ctx context.Context, d *schema.ResourceData, meta int
 	// Save Id
 	d.SetId(machine.SystemID)
 
+	// Commission machine
+	if err := commissionMachine(client, machine.SystemID, commissionParams); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Wait for machine to be ready
-	_, err = waitForMachineStatus(ctx, client, machine.SystemID, []string{"Commissioning", "Testing"}, []string{"Ready"}, d.Timeout(schema.TimeoutCreate))
+	targetStates := []string{"Ready"}
+	if d.Get("override_failed_testing").(bool) {
+		targetStates = append(targetStates, "Failed testing")
+	}
+	_, err = waitForMachineStatus(ctx, client, machine.SystemID, []string{"Commissioning", "Testing"}, targetStates, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -187,14 +496,55 @@ func resourceMachineRead(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.FromErr(err)
 	}
 
+	// Get machine power parameters. The write-only ones are never read back,
+	// so that they are not stored in the Terraform state. The ones given by a
+	// typed power configuration block are only stored in this block.
+	powerParams, err := client.Machine.GetPowerParameters(machine.SystemID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	powerDriver := getMachinePowerDriver(d)
+	if _, ok := d.GetOk("power_parameters_wo_version"); ok {
+		powerParams = map[string]interface{}{}
+	} else if powerDriver != "" {
+		configured := d.Get(powerDriver).([]interface{})[0].(map[string]interface{})
+		block := getMachinePowerDriverTFState(powerDriver, powerParams, configured, d.Get("ignore_power_parameters_defaults").(bool))
+		if err := d.Set(powerDriver, []interface{}{block}); err != nil {
+			return diag.FromErr(err)
+		}
+		powerParams = map[string]interface{}{}
+	} else if d.Get("ignore_power_parameters_defaults").(bool) {
+		configuredPowerParams, err := structure.ExpandJsonFromString(d.Get("power_parameters").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		powerParams = filterPowerParameters(powerParams, configuredPowerParams)
+	}
+	powerParamsString, err := structure.FlattenJsonToString(powerParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Set Terraform state
 	tfState := map[string]interface{}{
-		"architecture":   machine.Architecture,
-		"min_hwe_kernel": machine.MinHWEKernel,
-		"hostname":       machine.Hostname,
-		"domain":         machine.Domain.Name,
-		"zone":           machine.Zone.Name,
-		"pool":           machine.Pool.Name,
+		"power_type":          machine.PowerType,
+		"power_parameters":    powerParamsString,
+		"current_power_state": machine.PowerState,
+		"architecture":        machine.Architecture,
+		"min_hwe_kernel":      machine.MinHWEKernel,
+		"hostname":            machine.Hostname,
+		"domain":              machine.Domain.Name,
+		"zone":                machine.Zone.Name,
+		"pool":                machine.Pool.Name,
+	}
+	if d.Get("power_state").(string) != "unmanaged" {
+		tfState["power_state"] = machine.PowerState
+	}
+	for k, v := range getMachineHardwareTFState(machine) {
+		tfState[k] = v
+	}
+	if d.Get("desired_state").(string) != "unmanaged" {
+		tfState["desired_state"] = getMachineState(machine)
 	}
 	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
@@ -227,26 +577,204 @@ func resourceMachineUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 
+	// Re-commission machine
+	if d.HasChange("commission_trigger") && !d.IsNewResource() {
+		commissionParams, err := getMachineCommissionParams(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := commissionMachine(client, machine.SystemID, commissionParams); err != nil {
+			return diag.FromErr(err)
+		}
+		targetStates := []string{"Ready"}
+		if d.Get("override_failed_testing").(bool) {
+			targetStates = append(targetStates, "Failed testing")
+		}
+		_, err = waitForMachineStatus(ctx, client, machine.SystemID, []string{"Commissioning", "Testing"}, targetStates, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Update machine lifecycle state
+	if err := setMachineState(ctx, client, machine.SystemID, d.Get("desired_state").(string), d.Get("desired_state_comment").(string), d.Get("override_failed_testing").(bool), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Update machine power state
+	if d.HasChange("power_state") {
+		if err := setMachinePowerState(ctx, client, machine.SystemID, d.Get("power_type").(string), d.Get("power_state").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceMachineRead(ctx, d, meta)
 }
 
 func resourceMachineDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ClientConfig).Client
 
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("machine (%s) has deletion_protection enabled, set it to false and apply before destroying the machine", d.Id())
+	}
+
+	// Check if the machine can be safely deleted
+	machine, err := client.Machine.Get(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	releaseParams := getMachineReleaseParams(d)
+	action, err := getMachineDeleteAction(machine.StatusName, releaseParams != nil, d.Get("force_destroy").(bool))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("machine (%s): %w", machine.SystemID, err))
+	}
+
+	// Release machine
+	if action == "release" {
+		if _, err := client.Machine.Release(machine.SystemID, releaseParams); err != nil {
+			return diag.FromErr(err)
+		}
+		_, err = waitForMachineStatus(ctx, client, machine.SystemID, []string{machine.StatusName, "Releasing", "Disk erasing"}, []string{"Ready"}, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// Delete machine
-	if err := client.Machine.Delete(d.Id()); err != nil {
+	if err := client.Machine.Delete(machine.SystemID); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
+// getMachineDeleteAction returns the action needed before deleting a machine in the given status:
+// an empty string if the machine can be deleted right away, or `release` if it must be released first.
+// An error is returned if the machine is not safe to delete, and neither release nor force is set.
+func getMachineDeleteAction(statusName string, release bool, force bool) (string, error) {
+	switch statusName {
+	case "New", "Ready", "Broken":
+		return "", nil
+	case "Allocated", "Deployed", "Deploying", "Failed deployment":
+		if release {
+			return "release", nil
+		}
+	}
+	if force {
+		return "", nil
+	}
+	return "", fmt.Errorf("refusing to delete machine in status '%s', set release_on_destroy or force_destroy to delete it", statusName)
+}
+
+func getMachineReleaseParams(d *schema.ResourceData) *entity.MachineReleaseParams {
+	p, ok := d.GetOk("release_on_destroy")
+	if !ok || len(p.([]interface{})) == 0 {
+		return nil
+	}
+	params := &entity.MachineReleaseParams{}
+	if m, ok := p.([]interface{})[0].(map[string]interface{}); ok {
+		params.Comment = m["comment"].(string)
+		params.Erase = m["erase"].(bool)
+		params.QuickErase = m["quick_erase"].(bool)
+		params.SecureErase = m["secure_erase"].(bool)
+	}
+	return params
+}
+
+func getMachineHardwareTFState(machine *entity.Machine) map[string]interface{} {
+	blockDevices := make([]map[string]interface{}, len(machine.BlockDeviceSet))
+	for i, b := range machine.BlockDeviceSet {
+		blockDevices[i] = map[string]interface{}{
+			"id":             b.ID,
+			"name":           b.Name,
+			"model":          b.Model,
+			"serial":         b.Serial,
+			"id_path":        b.IDPath,
+			"path":           b.Path,
+			"size_gigabytes": int(b.Size / (1024 * 1024 * 1024)),
+			"type":           b.Type,
+			"tags":           b.Tags,
+			"numa_node":      b.NUMANode,
+		}
+	}
+	numaNodes := make([]map[string]interface{}, len(machine.NUMANodeSet))
+	for i, n := range machine.NUMANodeSet {
+		numaNodes[i] = map[string]interface{}{
+			"index":  n.Index,
+			"cores":  n.Cores,
+			"memory": n.Memory,
+		}
+	}
+	return map[string]interface{}{
+		"cpu_count":     machine.CPUCount,
+		"cpu_speed":     machine.CPUSpeed,
+		"memory":        machine.Memory,
+		"storage":       int(machine.Storage),
+		"block_devices": blockDevices,
+		"numa_nodes":    numaNodes,
+		"hardware_info": machine.HardwareInfo,
+		"status":        machine.StatusName,
+		"owner":         machine.Owner,
+	}
+}
+
+// powerParametersDiffSuppressFunc compares the power parameters as JSON objects.
+// The values are compared as strings, since MAAS returns all of them as strings.
+func powerParametersDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	oldMap, err := structure.ExpandJsonFromString(oldValue)
+	if err != nil {
+		return false
+	}
+	newMap, err := structure.ExpandJsonFromString(newValue)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(stringifyPowerParameters(oldMap), stringifyPowerParameters(newMap))
+}
+
+func stringifyPowerParameters(params map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(params))
+	for k, v := range params {
+		result[k] = fmt.Sprintf("%v", v)
+	}
+	return result
+}
+
+// filterPowerParameters returns the power parameters whose keys are present in the configured ones.
+func filterPowerParameters(params map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range params {
+		if _, ok := configured[k]; ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 func getMachinePowerParams(d *schema.ResourceData) (powerParams map[string]interface{}, err error) {
 	powerParams = make(map[string]interface{})
-	powerParamsString := d.Get("power_parameters").(string)
-	params, err := structure.ExpandJsonFromString(powerParamsString)
-	if err != nil {
-		return powerParams, err
+	var params map[string]interface{}
+	if _, ok := d.GetOk("power_parameters_wo_version"); ok {
+		// The write-only power parameters are only sent when they change,
+		// otherwise MAAS keeps the current ones.
+		if !d.IsNewResource() && !d.HasChange("power_parameters_wo_version") {
+			return powerParams, nil
+		}
+		powerParamsString, err := getWriteOnlyString(d, "power_parameters_wo")
+		if err != nil {
+			return powerParams, err
+		}
+		params, err = structure.ExpandJsonFromString(powerParamsString)
+		if err != nil {
+			return powerParams, err
+		}
+	} else if powerDriver := getMachinePowerDriver(d); powerDriver != "" {
+		params = getMachinePowerDriverParams(powerDriver, d.Get(powerDriver).([]interface{})[0].(map[string]interface{}))
+	} else {
+		params, err = structure.ExpandJsonFromString(d.Get("power_parameters").(string))
+		if err != nil {
+			return powerParams, err
+		}
 	}
 	for k, v := range params {
 		powerParams[fmt.Sprintf("power_parameters_%s", k)] = v
@@ -268,6 +796,66 @@ func getMachineParams(d *schema.ResourceData) *entity.MachineParams {
 	}
 }
 
+func getMachineCommissionParams(d *schema.ResourceData) (url.Values, error) {
+	qsp := url.Values{}
+	if scripts := convertToStringSlice(d.Get("commissioning_scripts").([]interface{})); len(scripts) > 0 {
+		qsp.Set("commissioning_scripts", strings.Join(scripts, ","))
+	}
+	if scripts := convertToStringSlice(d.Get("testing_scripts").([]interface{})); len(scripts) > 0 {
+		qsp.Set("testing_scripts", strings.Join(scripts, ","))
+	}
+	for _, k := range []string{"enable_ssh", "skip_bmc_config", "skip_networking", "skip_storage"} {
+		if d.Get(k).(bool) {
+			qsp.Set(k, "1")
+		}
+	}
+	for k, v := range d.Get("script_parameters").(map[string]interface{}) {
+		// The script parameters are validated at plan time, this only guards the commissioning options
+		if slices.Contains(machineCommissionOptions, k) {
+			return nil, fmt.Errorf("script parameter (%s) conflicts with the commissioning option of the same name", k)
+		}
+		qsp.Set(k, v.(string))
+	}
+	return qsp, nil
+}
+
+// isMachineScriptParameters checks that the script parameters don't use the name of a commissioning option,
+// since they are sent along with the commissioning options.
+func isMachineScriptParameters(i interface{}, p cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	params, ok := i.(map[string]interface{})
+	if !ok {
+		return diag.Errorf("expected type of script_parameters to be map")
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		if slices.Contains(machineCommissionOptions, k) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("script parameter (%s) conflicts with the commissioning option of the same name", k),
+				AttributePath: append(p, cty.IndexStep{Key: cty.StringVal(k)}),
+			})
+		}
+	}
+	return diags
+}
+
+// commissionMachine starts the machine commissioning. The MAAS API is called directly
+// since the script parameters cannot be passed with entity.MachineCommissionParams.
+func commissionMachine(client *client.Client, systemID string, params url.Values) error {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	return apiClient.GetSubObject("machines").GetSubObject(systemID).Post("commission", params, func(data []byte) error {
+		return nil
+	})
+}
+
 func getMachineStatusFunc(client *client.Client, systemId string) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		machine, err := client.Machine.Get(systemId)
@@ -296,6 +884,206 @@ func waitForMachineStatus(ctx context.Context, client *client.Client, systemID s
 	return result.(*entity.Machine), nil
 }
 
+// getMachineState returns the lifecycle state of the machine, using the same
+// values as the `desired_state` argument. Any other status is lowercased.
+func getMachineState(machine *entity.Machine) string {
+	if machine.Locked {
+		return "locked"
+	}
+	switch machine.StatusName {
+	case "Ready":
+		return "ready"
+	case "Broken":
+		return "broken"
+	case "Rescue mode":
+		return "rescue"
+	}
+	return strings.ToLower(machine.StatusName)
+}
+
+// getMachineStateTransitions computes the MAAS operations needed
+// to move a machine from its current status to the desired state.
+func getMachineStateTransitions(statusName string, locked bool, desiredState string, overrideFailedTesting bool) ([]string, error) {
+	transitions := []string{}
+	if statusName == "Failed testing" && overrideFailedTesting {
+		transitions = append(transitions, "override_failed_testing")
+		statusName = "Ready"
+	}
+	switch desiredState {
+	case "unmanaged":
+		return transitions, nil
+	case "locked":
+		if locked {
+			return transitions, nil
+		}
+		if statusName != "Deployed" {
+			return nil, fmt.Errorf("cannot lock machine in status '%s', only deployed machines can be locked", statusName)
+		}
+		return append(transitions, "lock"), nil
+	}
+	if locked {
+		transitions = append(transitions, "unlock")
+	}
+	switch desiredState {
+	case "ready":
+		switch statusName {
+		case "Ready":
+		case "Broken":
+			transitions = append(transitions, "mark_fixed")
+		case "Rescue mode":
+			transitions = append(transitions, "exit_rescue_mode")
+		case "Deployed":
+			return nil, fmt.Errorf("cannot change machine status from '%s' to ready, set desired_state to \"deployed\" to keep the machine deployed", statusName)
+		default:
+			return nil, fmt.Errorf("cannot change machine status from '%s' to ready", statusName)
+		}
+	case "deployed":
+		switch statusName {
+		case "Deployed":
+		case "Rescue mode":
+			transitions = append(transitions, "exit_rescue_mode")
+		default:
+			return nil, fmt.Errorf("cannot change machine status from '%s' to deployed, only deployed machines can be kept deployed", statusName)
+		}
+	case "broken":
+		if statusName != "Broken" {
+			transitions = append(transitions, "mark_broken")
+		}
+	case "rescue":
+		if statusName != "Rescue mode" {
+			transitions = append(transitions, "rescue_mode")
+		}
+	}
+	return transitions, nil
+}
+
+func setMachineState(ctx context.Context, client *client.Client, systemID string, desiredState string, comment string, overrideFailedTesting bool, maxTimeout time.Duration) error {
+	machine, err := client.Machine.Get(systemID)
+	if err != nil {
+		return err
+	}
+	transitions, err := getMachineStateTransitions(machine.StatusName, machine.Locked, desiredState, overrideFailedTesting)
+	if err != nil {
+		return fmt.Errorf("machine (%s): %w", systemID, err)
+	}
+	for _, transition := range transitions {
+		log.Printf("[DEBUG] Machine (%s) state transition: %s\n", systemID, transition)
+		switch transition {
+		case "override_failed_testing":
+			err = overrideMachineFailedTesting(client, systemID, comment)
+		case "lock":
+			_, err = client.Machine.Lock(systemID, comment)
+		case "unlock":
+			_, err = client.Machine.Unlock(systemID, comment)
+		case "mark_fixed":
+			_, err = client.Machine.MarkFixed(systemID, comment)
+		case "mark_broken":
+			_, err = client.Machine.MarkBroken(systemID, comment)
+		case "exit_rescue_mode":
+			if _, err = client.Machine.ExitRescueMode(systemID); err == nil {
+				if machine, err = waitForMachineStatus(ctx, client, systemID, []string{"Rescue mode", "Exiting rescue mode"}, []string{"Ready", "Deployed"}, maxTimeout); err == nil {
+					err = validateMachineRescueModeExit(machine, desiredState)
+				}
+			}
+		case "rescue_mode":
+			if _, err = client.Machine.RescueMode(systemID); err == nil {
+				_, err = waitForMachineStatus(ctx, client, systemID, []string{"Entering rescue mode"}, []string{"Rescue mode"}, maxTimeout)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateMachineRescueModeExit returns an error if the machine didn't exit the rescue mode in the desired state.
+// MAAS restores the status the machine had before entering the rescue mode, so a deployed machine can't be made ready this way.
+func validateMachineRescueModeExit(machine *entity.Machine, desiredState string) error {
+	if state := getMachineState(machine); state != desiredState {
+		return fmt.Errorf("machine (%s) exited rescue mode as %s, since it was in this status before entering rescue mode. Set desired_state to \"%s\" to keep this status", machine.SystemID, state, state)
+	}
+	return nil
+}
+
+func overrideMachineFailedTesting(client *client.Client, systemID string, comment string) error {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return err
+	}
+	qsp := url.Values{}
+	if comment != "" {
+		qsp.Set("comment", comment)
+	}
+	return apiClient.GetSubObject("machines").GetSubObject(systemID).Post("override_failed_testing", qsp, func(data []byte) error {
+		return nil
+	})
+}
+
+func setMachinePowerState(ctx context.Context, client *client.Client, systemID string, powerType string, powerState string, maxTimeout time.Duration) error {
+	if powerState == "" || powerState == "unmanaged" {
+		return nil
+	}
+	if powerType == "manual" {
+		return fmt.Errorf("machine (%s) power state cannot be managed with the 'manual' power type", systemID)
+	}
+
+	// Query the current power state from the BMC
+	state, err := client.Machine.GetPowerState(systemID)
+	if err != nil {
+		return err
+	}
+	action, pendingStates := getMachinePowerStateChange(state.State, powerState)
+	switch action {
+	case "on":
+		_, err = client.Machine.PowerOn(systemID, &entity.MachinePowerOnParams{Comment: "Powered on by Terraform"})
+	case "off":
+		_, err = client.Machine.PowerOff(systemID, &entity.MachinePowerOffParams{Comment: "Powered off by Terraform"})
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return waitForMachinePowerState(ctx, client, systemID, pendingStates, powerState, maxTimeout)
+}
+
+// getMachinePowerStateChange returns the power action (`on` or `off`) needed to move a machine
+// from its current power state to the desired one, and the pending states while it's applied.
+func getMachinePowerStateChange(currentState string, powerState string) (string, []string) {
+	if powerState == "" || powerState == "unmanaged" || currentState == powerState {
+		return "", nil
+	}
+	pendingStates := slices.DeleteFunc([]string{"on", "off", "unknown"}, func(s string) bool { return s == powerState })
+	return powerState, pendingStates
+}
+
+func getMachinePowerStateFunc(client *client.Client, systemID string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		state, err := client.Machine.GetPowerState(systemID)
+		if err != nil {
+			return nil, "", err
+		}
+		log.Printf("[DEBUG] Machine (%s) power state: %s\n", systemID, state.State)
+		return state, state.State, nil
+	}
+}
+
+func waitForMachinePowerState(ctx context.Context, client *client.Client, systemID string, pendingStates []string, powerState string, maxTimeout time.Duration) error {
+	log.Printf("[DEBUG] Waiting for machine (%s) power state to be %s\n", systemID, powerState)
+	stateConf := &retry.StateChangeConf{
+		Pending:    pendingStates,
+		Target:     []string{powerState},
+		Refresh:    getMachinePowerStateFunc(client, systemID),
+		Timeout:    maxTimeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
 func getMachine(client *client.Client, identifier string) (*entity.Machine, error) {
 	machines, err := client.Machines.Get(&entity.MachinesParams{})
 	if err != nil {
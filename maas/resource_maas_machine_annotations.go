@@ -0,0 +1,138 @@
+package maas
+
+import (
+	"context"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMaasMachineAnnotations() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage the owner data (workload annotations) of an allocated or deployed MAAS machine. This is useful for machines that are not deployed by a `maas_instance` resource.\n\n**NOTE:** MAAS clears all the workload annotations when the machine is released.",
+		CreateContext: resourceMachineAnnotationsCreate,
+		ReadContext:   resourceMachineAnnotationsRead,
+		UpdateContext: resourceMachineAnnotationsUpdate,
+		DeleteContext: resourceMachineAnnotationsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*ClientConfig).Client
+
+				machine, err := getMachine(client, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":      machine.SystemID,
+					"machine": machine.SystemID,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"annotations": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "A map of workload annotations (owner data) to set on the machine. Any other annotation found on the machine is removed.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (system ID, hostname, or FQDN) of the allocated or deployed machine.",
+			},
+		},
+	}
+}
+
+func resourceMachineAnnotationsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(machine.SystemID)
+
+	return resourceMachineAnnotationsUpdate(ctx, d, meta)
+}
+
+func resourceMachineAnnotationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := client.Machine.Get(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("annotations", machine.WorkloadAnnotations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMachineAnnotationsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	// Compare against the annotations found in MAAS, so that
+	// the ones added outside of Terraform are removed as well.
+	machine, err := client.Machine.Get(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setMachineWorkloadAnnotations(client, machine.SystemID, machine.WorkloadAnnotations, d.Get("annotations").(map[string]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceMachineAnnotationsRead(ctx, d, meta)
+}
+
+func resourceMachineAnnotationsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := client.Machine.Get(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setMachineWorkloadAnnotations(client, machine.SystemID, machine.WorkloadAnnotations, map[string]interface{}{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// setMachineWorkloadAnnotations sets the desired workload annotations on the machine.
+// MAAS removes an annotation when its value is set to an empty string, so the
+// current annotations missing from the desired ones are cleared this way.
+func setMachineWorkloadAnnotations(client *client.Client, systemID string, current map[string]string, desired map[string]interface{}) error {
+	params := getMachineWorkloadAnnotationsParams(current, desired)
+	if len(params) == 0 {
+		return nil
+	}
+	_, err := client.Machine.SetWorkloadAnnotations(systemID, params)
+	return err
+}
+
+func getMachineWorkloadAnnotationsParams(current map[string]string, desired map[string]interface{}) map[string]string {
+	params := map[string]string{}
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			params[k] = ""
+		}
+	}
+	for k, v := range desired {
+		if val, ok := current[k]; ok && val == v.(string) {
+			continue
+		}
+		params[k] = v.(string)
+	}
+	return params
+}
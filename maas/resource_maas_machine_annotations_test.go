@@ -0,0 +1,48 @@
+package maas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMachineWorkloadAnnotationsParams(t *testing.T) {
+	testCases := []struct {
+		name    string
+		current map[string]string
+		desired map[string]interface{}
+		out     map[string]string
+	}{
+		{
+			name:    "no annotations",
+			current: nil,
+			desired: map[string]interface{}{},
+			out:     map[string]string{},
+		},
+		{
+			name:    "new annotations are added",
+			current: nil,
+			desired: map[string]interface{}{"owner": "team-a", "cost_center": "42"},
+			out:     map[string]string{"owner": "team-a", "cost_center": "42"},
+		},
+		{
+			name:    "unchanged annotations are skipped",
+			current: map[string]string{"owner": "team-a", "cost_center": "42"},
+			desired: map[string]interface{}{"owner": "team-a", "cost_center": "43"},
+			out:     map[string]string{"cost_center": "43"},
+		},
+		{
+			name:    "missing annotations are cleared",
+			current: map[string]string{"owner": "team-a", "cost_center": "42"},
+			desired: map[string]interface{}{"owner": "team-a"},
+			out:     map[string]string{"cost_center": ""},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out := getMachineWorkloadAnnotationsParams(testCase.current, testCase.desired)
+			assert.Equal(t, testCase.out, out)
+		})
+	}
+}
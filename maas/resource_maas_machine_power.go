@@ -0,0 +1,219 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// machinePowerDriverParameter describes a power parameter of a typed power driver block.
+// The parameter name is the same as the one used by the MAAS API.
+type machinePowerDriverParameter struct {
+	Name        string
+	Description string
+	Required    bool
+	Sensitive   bool
+	List        bool
+	Default     string
+	Values      []string
+}
+
+// machinePowerDrivers contains the power drivers that can be configured with a typed block,
+// instead of the free-form `power_parameters` JSON string.
+var machinePowerDrivers = map[string][]machinePowerDriverParameter{
+	"amt": {
+		{Name: "power_address", Required: true, Description: "The IP address of the AMT interface."},
+		{Name: "power_pass", Sensitive: true, Description: "The AMT password."},
+	},
+	"ipmi": {
+		{Name: "cipher_suite_id", Values: []string{"", "3", "8", "12", "17"}, Description: "The IPMI cipher suite ID. Supported values are: `3`, `8`, `12`, `17`. If it's not set, `freeipmi` decides the cipher suite."},
+		{Name: "k_g", Sensitive: true, Description: "The IPMI K_g BMC key."},
+		{Name: "mac_address", Description: "The MAC address of the BMC."},
+		{Name: "power_address", Required: true, Description: "The IP address of the BMC."},
+		{Name: "power_boot_type", Default: "auto", Values: []string{"auto", "legacy", "efi"}, Description: "The boot type used when powering on the machine. Supported values are: `auto`, `legacy`, `efi`. Defaults to `auto`."},
+		{Name: "power_driver", Default: "LAN_2_0", Values: []string{"LAN", "LAN_2_0"}, Description: "The IPMI protocol version. Supported values are: `LAN` (IPMI 1.5), `LAN_2_0` (IPMI 2.0). Defaults to `LAN_2_0`."},
+		{Name: "power_pass", Sensitive: true, Description: "The BMC password."},
+		{Name: "power_user", Description: "The BMC user name."},
+		{Name: "privilege_level", Values: []string{"", "USER", "OPERATOR", "ADMIN"}, Description: "The IPMI privilege level. Supported values are: `USER`, `OPERATOR`, `ADMIN`."},
+		{Name: "workaround_flags", List: true, Values: []string{"opensesspriv", "authcap", "idzero", "unexpectedauth", "forcepermsg", "endianseq", "intel20", "supermicro20", "sun20", "nochecksumcheck", "integritycheckvalue", "ipmiping", "none"}, Description: "A set of workaround flags passed to `freeipmi` (e.g. `opensesspriv`)."},
+	},
+	"lxd": {
+		{Name: "certificate", Sensitive: true, Description: "The client certificate used to authenticate with LXD."},
+		{Name: "instance_name", Required: true, Description: "The LXD instance name."},
+		{Name: "key", Sensitive: true, Description: "The client private key used to authenticate with LXD."},
+		{Name: "password", Sensitive: true, Description: "The LXD trust password."},
+		{Name: "power_address", Required: true, Description: "The LXD server address (e.g. `https://10.0.0.10:8443`)."},
+		{Name: "project", Default: "default", Description: "The LXD project name. Defaults to `default`."},
+	},
+	"proxmox": {
+		{Name: "power_address", Required: true, Description: "The Proxmox host name or IP address."},
+		{Name: "power_pass", Sensitive: true, Description: "The Proxmox password."},
+		{Name: "power_token_name", Description: "The Proxmox API token name."},
+		{Name: "power_token_secret", Sensitive: true, Description: "The Proxmox API token secret."},
+		{Name: "power_user", Required: true, Description: "The Proxmox user name."},
+		{Name: "power_verify_ssl", Default: "n", Values: []string{"y", "n"}, Description: "Whether the Proxmox SSL certificate is verified. Supported values are: `y`, `n`. Defaults to `n`."},
+		{Name: "power_vm_name", Required: true, Description: "The Proxmox VM name or ID."},
+	},
+	"redfish": {
+		{Name: "node_id", Description: "The Redfish node ID."},
+		{Name: "power_address", Required: true, Description: "The IP address of the BMC."},
+		{Name: "power_pass", Sensitive: true, Description: "The BMC password."},
+		{Name: "power_user", Description: "The BMC user name."},
+	},
+	"virsh": {
+		{Name: "power_address", Required: true, Description: "The libvirt connection URI (e.g. `qemu+ssh://ubuntu@10.0.0.10/system`)."},
+		{Name: "power_id", Required: true, Description: "The libvirt domain name or ID."},
+		{Name: "power_pass", Sensitive: true, Description: "The password used to connect to libvirt."},
+	},
+	"webhook": {
+		{Name: "power_off_regex", Description: "The regular expression used to match the power query response when the machine is powered off."},
+		{Name: "power_off_uri", Required: true, Description: "The URI called to power off the machine."},
+		{Name: "power_on_regex", Description: "The regular expression used to match the power query response when the machine is powered on."},
+		{Name: "power_on_uri", Required: true, Description: "The URI called to power on the machine."},
+		{Name: "power_pass", Sensitive: true, Description: "The password used for basic authentication."},
+		{Name: "power_query_uri", Description: "The URI called to query the machine power state."},
+		{Name: "power_token", Sensitive: true, Description: "The bearer token used for authentication."},
+		{Name: "power_user", Description: "The user name used for basic authentication."},
+		{Name: "power_verify_ssl", Default: "n", Values: []string{"y", "n"}, Description: "Whether the SSL certificates are verified. Supported values are: `y`, `n`. Defaults to `n`."},
+	},
+}
+
+// getMachinePowerSources returns the arguments that can be used to configure the machine power parameters.
+func getMachinePowerSources() []string {
+	return append([]string{"power_parameters", "power_parameters_wo"}, getMachinePowerDriverNames()...)
+}
+
+func getMachinePowerDriverNames() []string {
+	names := make([]string, 0, len(machinePowerDrivers))
+	for name := range machinePowerDrivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getMachinePowerSchemas returns the typed power driver blocks of the `maas_machine` resource.
+func getMachinePowerSchemas() map[string]*schema.Schema {
+	powerSources := getMachinePowerSources()
+	schemas := make(map[string]*schema.Schema, len(machinePowerDrivers))
+	for driver, parameters := range machinePowerDrivers {
+		elemSchema := make(map[string]*schema.Schema, len(parameters))
+		for _, p := range parameters {
+			s := &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    !p.Required,
+				Required:    p.Required,
+				Sensitive:   p.Sensitive,
+				Description: p.Description,
+			}
+			if p.Default != "" {
+				s.Default = p.Default
+			}
+			if len(p.Values) > 0 && !p.List {
+				s.ValidateDiagFunc = validation.ToDiagFunc(validation.StringInSlice(p.Values, false))
+			}
+			if p.List {
+				s.Type = schema.TypeSet
+				s.Elem = &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(p.Values, false)),
+				}
+			}
+			elemSchema[p.Name] = s
+		}
+		schemas[driver] = &schema.Schema{
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ExactlyOneOf:  powerSources,
+			ConflictsWith: []string{"power_type"},
+			Description:   fmt.Sprintf("Typed power configuration for the `%s` power type, validated at plan time. When set, `power_type` is computed. Conflicts with `power_parameters`, `power_parameters_wo` and the other power type blocks.", driver),
+			Elem: &schema.Resource{
+				Schema: elemSchema,
+			},
+		}
+	}
+	return schemas
+}
+
+// resourceDataGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type resourceDataGetter interface {
+	GetOk(string) (interface{}, bool)
+}
+
+// getMachinePowerDriver returns the name of the typed power driver block set in the configuration, if any.
+func getMachinePowerDriver(d resourceDataGetter) string {
+	for _, driver := range getMachinePowerDriverNames() {
+		if v, ok := d.GetOk(driver); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+			return driver
+		}
+	}
+	return ""
+}
+
+// getMachinePowerDriverParams converts a typed power driver block to the MAAS power parameters.
+func getMachinePowerDriverParams(driver string, block map[string]interface{}) map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, p := range machinePowerDrivers[driver] {
+		if p.List {
+			if values := block[p.Name].(*schema.Set).List(); len(values) > 0 {
+				params[p.Name] = values
+			}
+			continue
+		}
+		if value := block[p.Name].(string); value != "" {
+			params[p.Name] = value
+		}
+	}
+	return params
+}
+
+// getMachinePowerDriverTFState converts the MAAS power parameters to a typed power driver block.
+// When ignoreDefaults is true, the parameters not set in the configured block are left empty.
+func getMachinePowerDriverTFState(driver string, params map[string]interface{}, configured map[string]interface{}, ignoreDefaults bool) map[string]interface{} {
+	block := make(map[string]interface{})
+	for _, p := range machinePowerDrivers[driver] {
+		var empty interface{} = ""
+		if p.List {
+			empty = []interface{}{}
+		}
+		block[p.Name] = empty
+		if ignoreDefaults {
+			switch v := configured[p.Name].(type) {
+			case string:
+				if v == "" {
+					continue
+				}
+			case *schema.Set:
+				if v.Len() == 0 {
+					continue
+				}
+			case nil:
+				continue
+			}
+		}
+		value, ok := params[p.Name]
+		if !ok {
+			continue
+		}
+		if p.List {
+			if values, ok := value.([]interface{}); ok {
+				block[p.Name] = values
+			}
+			continue
+		}
+		block[p.Name] = fmt.Sprintf("%v", value)
+	}
+	return block
+}
+
+func resourceMachineCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The power type is computed from the typed power driver block.
+	if driver := getMachinePowerDriver(d); driver != "" && d.Get("power_type").(string) != driver {
+		return d.SetNew("power_type", driver)
+	}
+	return nil
+}
@@ -0,0 +1,637 @@
+package maas
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMachineCommissionParams(t *testing.T) {
+	testCases := []struct {
+		name string
+		raw  map[string]interface{}
+		out  url.Values
+		err  bool
+	}{
+		{
+			name: "defaults",
+			raw:  map[string]interface{}{},
+			out:  url.Values{},
+		},
+		{
+			name: "all options",
+			raw: map[string]interface{}{
+				"commissioning_scripts": []interface{}{"update_firmware", "configure-hba"},
+				"testing_scripts":       []interface{}{"fio"},
+				"script_parameters":     map[string]interface{}{"fio_storage": "sda"},
+				"enable_ssh":            true,
+				"skip_bmc_config":       true,
+				"skip_networking":       false,
+				"skip_storage":          true,
+			},
+			out: url.Values{
+				"commissioning_scripts": {"update_firmware,configure-hba"},
+				"testing_scripts":       {"fio"},
+				"fio_storage":           {"sda"},
+				"enable_ssh":            {"1"},
+				"skip_bmc_config":       {"1"},
+				"skip_storage":          {"1"},
+			},
+		},
+		{
+			name: "script parameter named after an option",
+			raw: map[string]interface{}{
+				"enable_ssh":        false,
+				"script_parameters": map[string]interface{}{"enable_ssh": "1"},
+			},
+			err: true,
+		},
+		{
+			name: "script parameter named after the scripts",
+			raw: map[string]interface{}{
+				"testing_scripts":   []interface{}{"fio"},
+				"script_parameters": map[string]interface{}{"testing_scripts": "smartctl-validate"},
+			},
+			err: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceMaasMachine().Schema, testCase.raw)
+			out, err := getMachineCommissionParams(d)
+			if testCase.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.out, out)
+		})
+	}
+}
+
+func TestGetMachineStateTransitions(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		statusName            string
+		locked                bool
+		desiredState          string
+		overrideFailedTesting bool
+		out                   []string
+		err                   bool
+	}{
+		{
+			name:         "unmanaged state is a no-op",
+			statusName:   "Broken",
+			desiredState: "unmanaged",
+			out:          []string{},
+		},
+		{
+			name:         "ready machine is already ready",
+			statusName:   "Ready",
+			desiredState: "ready",
+			out:          []string{},
+		},
+		{
+			name:         "broken machine is marked fixed",
+			statusName:   "Broken",
+			desiredState: "ready",
+			out:          []string{"mark_fixed"},
+		},
+		{
+			name:         "rescue mode is exited",
+			statusName:   "Rescue mode",
+			desiredState: "ready",
+			out:          []string{"exit_rescue_mode"},
+		},
+		{
+			name:         "deployed machine cannot be made ready",
+			statusName:   "Deployed",
+			desiredState: "ready",
+			err:          true,
+		},
+		{
+			name:         "rescue mode is exited to keep the machine deployed",
+			statusName:   "Rescue mode",
+			desiredState: "deployed",
+			out:          []string{"exit_rescue_mode"},
+		},
+		{
+			name:         "deployed machine is already deployed",
+			statusName:   "Deployed",
+			desiredState: "deployed",
+			out:          []string{},
+		},
+		{
+			name:         "locked machine is unlocked to be kept deployed",
+			statusName:   "Deployed",
+			locked:       true,
+			desiredState: "deployed",
+			out:          []string{"unlock"},
+		},
+		{
+			name:         "ready machine cannot be made deployed",
+			statusName:   "Ready",
+			desiredState: "deployed",
+			err:          true,
+		},
+		{
+			name:         "failed testing cannot be made ready without override",
+			statusName:   "Failed testing",
+			desiredState: "ready",
+			err:          true,
+		},
+		{
+			name:                  "failed testing is overridden",
+			statusName:            "Failed testing",
+			desiredState:          "ready",
+			overrideFailedTesting: true,
+			out:                   []string{"override_failed_testing"},
+		},
+		{
+			name:                  "failed testing is overridden with unmanaged state",
+			statusName:            "Failed testing",
+			desiredState:          "unmanaged",
+			overrideFailedTesting: true,
+			out:                   []string{"override_failed_testing"},
+		},
+		{
+			name:         "ready machine is marked broken",
+			statusName:   "Ready",
+			desiredState: "broken",
+			out:          []string{"mark_broken"},
+		},
+		{
+			name:         "locked machine is unlocked before being marked broken",
+			statusName:   "Deployed",
+			locked:       true,
+			desiredState: "broken",
+			out:          []string{"unlock", "mark_broken"},
+		},
+		{
+			name:         "deployed machine is locked",
+			statusName:   "Deployed",
+			desiredState: "locked",
+			out:          []string{"lock"},
+		},
+		{
+			name:         "ready machine cannot be locked",
+			statusName:   "Ready",
+			desiredState: "locked",
+			err:          true,
+		},
+		{
+			name:         "ready machine enters rescue mode",
+			statusName:   "Ready",
+			desiredState: "rescue",
+			out:          []string{"rescue_mode"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out, err := getMachineStateTransitions(testCase.statusName, testCase.locked, testCase.desiredState, testCase.overrideFailedTesting)
+			if testCase.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.out, out)
+		})
+	}
+}
+
+func TestValidateMachineRescueModeExit(t *testing.T) {
+	testCases := []struct {
+		name         string
+		statusName   string
+		desiredState string
+		err          string
+	}{
+		{
+			name:         "ready machine exits as ready",
+			statusName:   "Ready",
+			desiredState: "ready",
+		},
+		{
+			name:         "deployed machine exits as deployed",
+			statusName:   "Deployed",
+			desiredState: "deployed",
+		},
+		{
+			name:         "deployed machine cannot exit as ready",
+			statusName:   "Deployed",
+			desiredState: "ready",
+			err:          "machine (abc123) exited rescue mode as deployed, since it was in this status before entering rescue mode. Set desired_state to \"deployed\" to keep this status",
+		},
+		{
+			name:         "ready machine cannot exit as deployed",
+			statusName:   "Ready",
+			desiredState: "deployed",
+			err:          "machine (abc123) exited rescue mode as ready, since it was in this status before entering rescue mode. Set desired_state to \"ready\" to keep this status",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateMachineRescueModeExit(&entity.Machine{SystemID: "abc123", StatusName: testCase.statusName}, testCase.desiredState)
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPowerParametersDiffSuppressFunc(t *testing.T) {
+	testCases := []struct {
+		name     string
+		oldValue string
+		newValue string
+		out      bool
+	}{
+		{
+			name:     "key order is ignored",
+			oldValue: `{"power_address":"10.0.0.1","power_user":"admin"}`,
+			newValue: `{"power_user":"admin","power_address":"10.0.0.1"}`,
+			out:      true,
+		},
+		{
+			name:     "values are compared as strings",
+			oldValue: `{"power_id":"1","power_boot_type":"auto"}`,
+			newValue: `{"power_id":1,"power_boot_type":"auto"}`,
+			out:      true,
+		},
+		{
+			name:     "changed value",
+			oldValue: `{"power_address":"10.0.0.1"}`,
+			newValue: `{"power_address":"10.0.0.2"}`,
+			out:      false,
+		},
+		{
+			name:     "extra key",
+			oldValue: `{"power_address":"10.0.0.1","power_driver":"LAN_2_0"}`,
+			newValue: `{"power_address":"10.0.0.1"}`,
+			out:      false,
+		},
+		{
+			name:     "invalid JSON",
+			oldValue: `{"power_address":"10.0.0.1"}`,
+			newValue: `{"power_address":`,
+			out:      false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, powerParametersDiffSuppressFunc("power_parameters", testCase.oldValue, testCase.newValue, nil))
+		})
+	}
+}
+
+func TestFilterPowerParameters(t *testing.T) {
+	params := map[string]interface{}{
+		"power_address":    "10.0.0.1",
+		"power_user":       "admin",
+		"power_driver":     "LAN_2_0",
+		"cipher_suite_id":  "3",
+		"privilege_level":  "OPERATOR",
+		"power_boot_type":  "auto",
+		"k_g":              "",
+		"workaround_flags": []interface{}{"opensesspriv"},
+	}
+	configured := map[string]interface{}{
+		"power_address": "10.0.0.2",
+		"power_user":    "admin",
+		"power_pass":    "secret",
+	}
+	expected := map[string]interface{}{
+		"power_address": "10.0.0.1",
+		"power_user":    "admin",
+	}
+	assert.Equal(t, expected, filterPowerParameters(params, configured))
+}
+
+func TestGetMachinePowerParams(t *testing.T) {
+	testCases := []struct {
+		name string
+		raw  map[string]interface{}
+		out  map[string]interface{}
+	}{
+		{
+			name: "power_parameters",
+			raw: map[string]interface{}{
+				"power_type":       "virsh",
+				"power_parameters": `{"power_address": "qemu+ssh://ubuntu@10.0.0.10/system", "power_id": "vm1"}`,
+			},
+			out: map[string]interface{}{
+				"power_parameters_power_address": "qemu+ssh://ubuntu@10.0.0.10/system",
+				"power_parameters_power_id":      "vm1",
+			},
+		},
+		{
+			name: "ipmi block",
+			raw: map[string]interface{}{
+				"ipmi": []interface{}{
+					map[string]interface{}{
+						"power_address":    "10.0.0.1",
+						"power_user":       "admin",
+						"power_pass":       "secret",
+						"cipher_suite_id":  "17",
+						"workaround_flags": []interface{}{"opensesspriv"},
+					},
+				},
+			},
+			out: map[string]interface{}{
+				"power_parameters_power_address":    "10.0.0.1",
+				"power_parameters_power_user":       "admin",
+				"power_parameters_power_pass":       "secret",
+				"power_parameters_cipher_suite_id":  "17",
+				"power_parameters_power_boot_type":  "auto",
+				"power_parameters_power_driver":     "LAN_2_0",
+				"power_parameters_workaround_flags": []interface{}{"opensesspriv"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceMaasMachine().Schema, testCase.raw)
+			powerParams, err := getMachinePowerParams(d)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.out, powerParams)
+		})
+	}
+}
+
+func TestGetMachinePowerDriverTFState(t *testing.T) {
+	params := map[string]interface{}{
+		"power_address": "qemu+ssh://ubuntu@10.0.0.10/system",
+		"power_id":      "vm1",
+		"power_pass":    "secret",
+	}
+	configured := map[string]interface{}{
+		"power_address": "qemu+ssh://ubuntu@10.0.0.11/system",
+		"power_id":      "vm1",
+		"power_pass":    "",
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"power_address": "qemu+ssh://ubuntu@10.0.0.10/system",
+		"power_id":      "vm1",
+		"power_pass":    "",
+	}, getMachinePowerDriverTFState("virsh", params, configured, true))
+	assert.Equal(t, map[string]interface{}{
+		"power_address": "qemu+ssh://ubuntu@10.0.0.10/system",
+		"power_id":      "vm1",
+		"power_pass":    "secret",
+	}, getMachinePowerDriverTFState("virsh", params, configured, false))
+}
+
+func TestGetMachineDeleteAction(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusName string
+		release    bool
+		force      bool
+		action     string
+		err        bool
+	}{
+		{name: "ready", statusName: "Ready"},
+		{name: "new", statusName: "New"},
+		{name: "broken", statusName: "Broken"},
+		{name: "deployed", statusName: "Deployed", err: true},
+		{name: "deployed with release", statusName: "Deployed", release: true, action: "release"},
+		{name: "deployed with force", statusName: "Deployed", force: true},
+		{name: "allocated with release and force", statusName: "Allocated", release: true, force: true, action: "release"},
+		{name: "commissioning with release", statusName: "Commissioning", release: true, err: true},
+		{name: "commissioning with force", statusName: "Commissioning", force: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			action, err := getMachineDeleteAction(testCase.statusName, testCase.release, testCase.force)
+			if testCase.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.action, action)
+		})
+	}
+}
+
+func TestGetMachinePowerStateChange(t *testing.T) {
+	testCases := []struct {
+		name          string
+		currentState  string
+		powerState    string
+		action        string
+		pendingStates []string
+	}{
+		{name: "on to off", currentState: "on", powerState: "off", action: "off", pendingStates: []string{"on", "unknown"}},
+		{name: "off to on", currentState: "off", powerState: "on", action: "on", pendingStates: []string{"off", "unknown"}},
+		{name: "unknown to on", currentState: "unknown", powerState: "on", action: "on", pendingStates: []string{"off", "unknown"}},
+		{name: "already on", currentState: "on", powerState: "on"},
+		{name: "already off", currentState: "off", powerState: "off"},
+		{name: "unmanaged", currentState: "on", powerState: "unmanaged"},
+		{name: "not set", currentState: "off", powerState: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			action, pendingStates := getMachinePowerStateChange(testCase.currentState, testCase.powerState)
+			assert.Equal(t, testCase.action, action)
+			assert.Equal(t, testCase.pendingStates, pendingStates)
+		})
+	}
+}
+
+func TestGetMachineHardwareTFState(t *testing.T) {
+	testCases := []struct {
+		name    string
+		machine *entity.Machine
+		out     map[string]interface{}
+	}{
+		{
+			name:    "empty hardware info",
+			machine: &entity.Machine{StatusName: "New"},
+			out: map[string]interface{}{
+				"cpu_count":     0,
+				"cpu_speed":     0,
+				"memory":        int64(0),
+				"storage":       0,
+				"block_devices": []map[string]interface{}{},
+				"numa_nodes":    []map[string]interface{}{},
+				"hardware_info": map[string]string(nil),
+				"status":        "New",
+				"owner":         "",
+			},
+		},
+		{
+			name: "commissioned machine",
+			machine: &entity.Machine{
+				CPUCount:     8,
+				CPUSpeed:     2400,
+				Memory:       16384,
+				Storage:      512000.5,
+				HardwareInfo: map[string]string{"system_vendor": "Dell Inc."},
+				StatusName:   "Deployed",
+				Owner:        "admin",
+				BlockDeviceSet: []entity.BlockDevice{
+					{ID: 1, Name: "sda", Model: "SSD", Serial: "S1", IDPath: "/dev/disk/by-id/wwn-1", Path: "/dev/disk/by-dname/sda", Size: 512 * 1024 * 1024 * 1024, Type: "physical", Tags: []string{"ssd"}},
+				},
+				NUMANodeSet: []entity.NUMANode{
+					{Index: 0, Cores: []int{0, 1, 2, 3}, Memory: 8192},
+				},
+			},
+			out: map[string]interface{}{
+				"cpu_count": 8,
+				"cpu_speed": 2400,
+				"memory":    int64(16384),
+				"storage":   512000,
+				"block_devices": []map[string]interface{}{
+					{
+						"id":             1,
+						"name":           "sda",
+						"model":          "SSD",
+						"serial":         "S1",
+						"id_path":        "/dev/disk/by-id/wwn-1",
+						"path":           "/dev/disk/by-dname/sda",
+						"size_gigabytes": 512,
+						"type":           "physical",
+						"tags":           []string{"ssd"},
+						"numa_node":      0,
+					},
+				},
+				"numa_nodes": []map[string]interface{}{
+					{"index": 0, "cores": []int{0, 1, 2, 3}, "memory": 8192},
+				},
+				"hardware_info": map[string]string{"system_vendor": "Dell Inc."},
+				"status":        "Deployed",
+				"owner":         "admin",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out := getMachineHardwareTFState(testCase.machine)
+			assert.Equal(t, testCase.out, out)
+
+			// The state must be accepted by the resource schema
+			d := schema.TestResourceDataRaw(t, resourceMaasMachine().Schema, map[string]interface{}{})
+			assert.NoError(t, setTerraformState(d, out))
+		})
+	}
+}
+
+func TestGetMachinePowerParamsWriteOnly(t *testing.T) {
+	state := map[string]string{
+		"power_type":                  "ipmi",
+		"pxe_mac_address":             "52:54:00:00:00:01",
+		"power_parameters_wo_version": "1",
+	}
+	rawConfig := map[string]cty.Value{
+		"power_parameters_wo": cty.StringVal(`{"power_address": "10.0.0.1", "power_pass": "secret"}`),
+	}
+	testCases := []struct {
+		name       string
+		state      map[string]string
+		attributes map[string]*terraform.ResourceAttrDiff
+		out        map[string]interface{}
+	}{
+		{
+			name: "create",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"power_type":                  {New: "ipmi"},
+				"pxe_mac_address":             {New: "52:54:00:00:00:01"},
+				"power_parameters_wo_version": {New: "1"},
+			},
+			out: map[string]interface{}{
+				"power_parameters_power_address": "10.0.0.1",
+				"power_parameters_power_pass":    "secret",
+			},
+		},
+		{
+			name:  "update with the same version",
+			state: state,
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"zone": {Old: "default", New: "rack-01"},
+			},
+			out: map[string]interface{}{},
+		},
+		{
+			name:  "update with a new version",
+			state: state,
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"power_parameters_wo_version": {Old: "1", New: "2"},
+			},
+			out: map[string]interface{}{
+				"power_parameters_power_address": "10.0.0.1",
+				"power_parameters_power_pass":    "secret",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := testApplyResourceData(t, resourceMaasMachine(), testCase.state, testCase.attributes, rawConfig)
+			powerParams, err := getMachinePowerParams(d)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.out, powerParams)
+		})
+	}
+}
+
+func TestIsMachineScriptParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]interface{}
+		errs   []string
+	}{
+		{
+			name:   "script parameters",
+			params: map[string]interface{}{"fio_storage": "all", "smartctl-validate_storage": "sda"},
+		},
+		{
+			name:   "commissioning options",
+			params: map[string]interface{}{"fio_storage": "all", "skip_storage": "1", "enable_ssh": "1"},
+			errs: []string{
+				"script parameter (enable_ssh) conflicts with the commissioning option of the same name",
+				"script parameter (skip_storage) conflicts with the commissioning option of the same name",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diags := isMachineScriptParameters(testCase.params, cty.GetAttrPath("script_parameters"))
+			var errs []string
+			for _, d := range diags {
+				errs = append(errs, d.Summary)
+			}
+			assert.Equal(t, testCase.errs, errs)
+		})
+	}
+}
+
+func TestMachineScriptParametersPlanValidation(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"power_type":        "manual",
+		"pxe_mac_address":   "52:54:00:00:00:01",
+		"script_parameters": map[string]interface{}{"enable_ssh": "1"},
+	})
+	diags := resourceMaasMachine().Validate(config)
+	summaries := []string{}
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary)
+	}
+	assert.Contains(t, summaries, "script parameter (enable_ssh) conflicts with the commissioning option of the same name")
+}
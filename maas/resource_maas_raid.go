@@ -0,0 +1,285 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMaasRAID() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS machines' software RAID arrays.",
+		CreateContext: resourceRAIDCreate,
+		ReadContext:   resourceRAIDRead,
+		UpdateContext: resourceRAIDUpdate,
+		DeleteContext: resourceRAIDDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:RAID", d.Id())
+				}
+				client := meta.(*ClientConfig).Client
+
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				raid, err := getRAID(client, machine.SystemID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", raid.ID),
+					"machine": machine.SystemID,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"block_device_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the block device of the RAID array.",
+			},
+			"block_devices": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"block_devices", "partitions"},
+				Description:  "A set of block devices (ID, name, ID path or path) used as active members of the RAID array. This is computed if it's not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"fs_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file system type (e.g. `ext4`) of the RAID array. If this is not set, the RAID array is unformatted.",
+			},
+			"level": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"raid-0", "raid-1", "raid-5", "raid-6", "raid-10"}, false)),
+				Description:      "The RAID level. Supported values are: `raid-0`, `raid-1`, `raid-5`, `raid-6`, `raid-10`.",
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The machine identifier (system ID, hostname, or FQDN) that owns the RAID array.",
+			},
+			"mount_options": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"mount_point"},
+				Description:  "The options used for the RAID array mount.",
+			},
+			"mount_point": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"fs_type"},
+				Description:  "The mount point used. If this is not set, the RAID array is not mounted. This is used only if the RAID array is formatted.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The RAID array name (e.g. `md0`).",
+			},
+			"partitions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "A set of partitions (ID, name or path) used as active members of the RAID array. This is computed if it's not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the block device of the RAID array.",
+			},
+			"size_gigabytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the RAID array (given in GB).",
+			},
+			"spare_devices": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "A set of block devices (ID, name, ID path or path) used as spare members of the RAID array. This is computed if it's not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"spare_partitions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "A set of partitions (ID, name or path) used as spare members of the RAID array. This is computed if it's not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The RAID array UUID.",
+			},
+		},
+	}
+}
+
+func resourceRAIDCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	members, err := getStorageMemberIDs(client, machine.SystemID, d, []string{"block_devices", "spare_devices"}, []string{"partitions", "spare_partitions"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := entity.RAIDCreateParams{
+		Name:            d.Get("name").(string),
+		Level:           d.Get("level").(string),
+		BlockDevices:    members["block_devices"],
+		Partitions:      members["partitions"],
+		SpareDevices:    members["spare_devices"],
+		SparePartitions: members["spare_partitions"],
+	}
+	raid, err := client.RAIDs.Create(machine.SystemID, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", raid.ID))
+
+	return resourceRAIDUpdate(ctx, d, meta)
+}
+
+func resourceRAIDRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	raid, err := client.RAID.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	devices, partitions := splitStorageMembers(raid.Devices)
+	spareDevices, sparePartitions := splitStorageMembers(raid.SpareDevices)
+	tfState := map[string]interface{}{
+		"name":             raid.Name,
+		"level":            raid.Level,
+		"uuid":             raid.UUID,
+		"size_gigabytes":   int(raid.Size / (1024 * 1024 * 1024)),
+		"block_device_id":  raid.VirtualDevice.ID,
+		"path":             raid.VirtualDevice.Path,
+		"fs_type":          raid.VirtualDevice.Filesystem.FSType,
+		"mount_point":      raid.VirtualDevice.Filesystem.MountPoint,
+		"mount_options":    raid.VirtualDevice.Filesystem.MountOptions,
+		"block_devices":    getStorageMembersTFState(devices, convertToStringSlice(d.Get("block_devices").(*schema.Set).List())),
+		"partitions":       getStorageMembersTFState(partitions, convertToStringSlice(d.Get("partitions").(*schema.Set).List())),
+		"spare_devices":    getStorageMembersTFState(spareDevices, convertToStringSlice(d.Get("spare_devices").(*schema.Set).List())),
+		"spare_partitions": getStorageMembersTFState(sparePartitions, convertToStringSlice(d.Get("spare_partitions").(*schema.Set).List())),
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceRAIDUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	raid, err := client.RAID.Get(machine.SystemID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Update the name and the members of the RAID array
+	members, err := getStorageMemberIDs(client, machine.SystemID, d, []string{"block_devices", "spare_devices"}, []string{"partitions", "spare_partitions"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	devices, partitions := splitStorageMembers(raid.Devices)
+	spareDevices, sparePartitions := splitStorageMembers(raid.SpareDevices)
+	params := entity.RAIDUpdateParams{Name: d.Get("name").(string)}
+	params.AddBlockDevices, params.RemoveBlockDevices = getStorageMemberChanges(devices, members["block_devices"])
+	params.AddPartitions, params.RemovePartitions = getStorageMemberChanges(partitions, members["partitions"])
+	params.AddSpareDevices, params.RemoveSpareDevices = getStorageMemberChanges(spareDevices, members["spare_devices"])
+	params.AddSparePartitions, params.RemoveSparePartitions = getStorageMemberChanges(sparePartitions, members["spare_partitions"])
+	raid, err = client.RAID.Update(machine.SystemID, id, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Update the file system of the RAID array
+	if err := updateBlockDeviceFilesystem(client, machine.SystemID, &raid.VirtualDevice, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceRAIDRead(ctx, d, meta)
+}
+
+func resourceRAIDDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.RAID.Delete(machine.SystemID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getRAID(client *client.Client, machineID string, identifier string) (*entity.RAID, error) {
+	raids, err := client.RAIDs.Get(machineID)
+	if err != nil {
+		return nil, err
+	}
+	for _, raid := range raids {
+		if fmt.Sprintf("%v", raid.ID) == identifier || raid.Name == identifier {
+			return &raid, nil
+		}
+	}
+	return nil, fmt.Errorf("RAID (%s) was not found on machine (%s)", identifier, machineID)
+}
@@ -0,0 +1,166 @@
+package maas_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-maas/maas"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccMaasRAID(machine string, name string, mountPoint string) string {
+	return fmt.Sprintf(`
+data "maas_machine" "machine" {
+  hostname = "%s"
+}
+
+resource "maas_block_device" "vdb" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdb"
+  size_gigabytes = 10
+  id_path        = "/dev/vdb"
+}
+
+resource "maas_block_device" "vdc" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdc"
+  size_gigabytes = 10
+  id_path        = "/dev/vdc"
+}
+
+resource "maas_raid" "test" {
+  machine       = data.maas_machine.machine.id
+  name          = "%s"
+  level         = "raid-1"
+  block_devices = [maas_block_device.vdb.name, maas_block_device.vdc.name]
+  fs_type       = "ext4"
+  mount_point   = "%s"
+}
+`, machine, name, mountPoint)
+}
+
+func TestAccResourceMaasRAID_basic(t *testing.T) {
+
+	var raid entity.RAID
+	machine := os.Getenv("TF_ACC_BLOCK_DEVICE_MACHINE")
+
+	checks := []resource.TestCheckFunc{
+		testAccMaasRAIDCheckExists("maas_raid.test", &raid),
+		resource.TestCheckResourceAttr("maas_raid.test", "level", "raid-1"),
+		resource.TestCheckResourceAttr("maas_raid.test", "block_devices.#", "2"),
+		resource.TestCheckResourceAttr("maas_raid.test", "partitions.#", "0"),
+		resource.TestCheckResourceAttr("maas_raid.test", "fs_type", "ext4"),
+		resource.TestCheckResourceAttrSet("maas_raid.test", "block_device_id"),
+		resource.TestCheckResourceAttrSet("maas_raid.test", "uuid"),
+		resource.TestCheckResourceAttrPair("maas_raid.test", "machine", "data.maas_machine.machine", "id"),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, []string{"TF_ACC_BLOCK_DEVICE_MACHINE"}) },
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMaasRAIDDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaasRAID(machine, "md0", "/srv"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks,
+						resource.TestCheckResourceAttr("maas_raid.test", "name", "md0"),
+						resource.TestCheckResourceAttr("maas_raid.test", "mount_point", "/srv"))...),
+			},
+			// Test update
+			{
+				Config: testAccMaasRAID(machine, "md1", "/data"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks,
+						resource.TestCheckResourceAttr("maas_raid.test", "name", "md1"),
+						resource.TestCheckResourceAttr("maas_raid.test", "mount_point", "/data"))...),
+			},
+			// Test import
+			{
+				ResourceName:      "maas_raid.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["maas_raid.test"]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", "maas_raid.test")
+					}
+
+					if rs.Primary.ID == "" {
+						return "", fmt.Errorf("resource id not set")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["machine"], rs.Primary.Attributes["id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccMaasRAIDCheckExists(rn string, raid *entity.RAID) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		gotRAID, err := conn.RAID.Get(rs.Primary.Attributes["machine"], id)
+		if err != nil {
+			return fmt.Errorf("error getting RAID: %s", err)
+		}
+
+		*raid = *gotRAID
+
+		return nil
+	}
+}
+
+func testAccCheckMaasRAIDDestroy(s *terraform.State) error {
+	// retrieve the connection established in Provider configuration
+	conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+
+	// loop through the resources in state, verifying each maas_raid
+	// is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "maas_raid" {
+			continue
+		}
+
+		// Retrieve our maas_raid by referencing it's state ID for API lookup
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		response, err := conn.RAID.Get(rs.Primary.Attributes["machine"], id)
+		if err == nil {
+			if response != nil && response.ID == id {
+				return fmt.Errorf("MAAS RAID (%s) still exists.", rs.Primary.ID)
+			}
+
+			return nil
+		}
+
+		// If the error is equivalent to 404 not found, the maas_raid is destroyed.
+		// Otherwise return the error
+		if !strings.Contains(err.Error(), "404 Not Found") {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -6,16 +6,22 @@ import (
 
 	"github.com/canonical/gomaasclient/client"
 	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceMaasUser() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Provides a resource to manage MAAS users.",
+		Description:   "Provides a resource to manage MAAS users. MAAS cannot change the password of another user, so changing the password re-creates the user.",
 		CreateContext: resourceUserCreate,
 		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("password"), cty.GetAttrPath("password_wo")),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				client := meta.(*ClientConfig).Client
@@ -59,11 +65,27 @@ func resourceMaasUser() *schema.Resource {
 				Description: "The user name.",
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				ForceNew:    true,
-				Description: "The user password.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description:  "The user password. This is stored in the Terraform state, consider using `password_wo` instead. Exactly one of `password` and `password_wo` must be set. Since MAAS cannot change the password of another user, changing it re-creates the user, and the user loses its SSH keys, API tokens and machines ownership.",
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description:  "The write-only user password. This is never stored in the Terraform state, and it can be set from an ephemeral value. Requires Terraform 1.11 or later. Change `password_wo_version` to re-create the user with a new password.",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "An arbitrary version number of the `password_wo` value. Since MAAS cannot change the password of another user, changing it re-creates the user with the current `password_wo` value: the plan shows the user replacement, and the user loses its SSH keys, API tokens and machines ownership.",
 			},
 		},
 	}
@@ -72,7 +94,15 @@ func resourceMaasUser() *schema.Resource {
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ClientConfig).Client
 
-	user, err := client.Users.Create(getUserParams(d))
+	userParams := getUserParams(d)
+	if userParams.Password == "" {
+		password, err := getWriteOnlyString(d, "password_wo")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		userParams.Password = password
+	}
+	user, err := client.Users.Create(userParams)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -91,6 +121,12 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	return nil
 }
 
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The write-only password is the only argument that is not forcing a new
+	// resource, and it is never stored, so there is nothing to update in MAAS.
+	return resourceUserRead(ctx, d, meta)
+}
+
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ClientConfig).Client
 
@@ -0,0 +1,456 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// vmCluster is the MAAS VM cluster object, which is not supported by gomaasclient.
+type vmCluster struct {
+	ID      int             `json:"id"`
+	Name    string          `json:"name"`
+	Project string          `json:"project"`
+	Pool    vmClusterField  `json:"pool"`
+	Zone    vmClusterField  `json:"zone"`
+	Hosts   []vmClusterHost `json:"hosts"`
+}
+
+// vmClusterHost is a member of a MAAS VM cluster.
+type vmClusterHost struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// vmClusterField is the name of an object referenced by a VM cluster, which MAAS
+// returns either as the object itself or as its name.
+type vmClusterField string
+
+func (f *vmClusterField) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*f = vmClusterField(name)
+		return nil
+	}
+	var object struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*f = vmClusterField(object.Name)
+	return nil
+}
+
+func resourceMaasVMCluster() *schema.Resource {
+	vmClusterSchema := getVMClusterTFSchema()
+	vmClusterSchema["certificate"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		RequiredWith: []string{"key"},
+		Description:  "The PEM encoded client certificate used by MAAS to connect to the LXD cluster. It must be in the LXD trust store, unless `password` or `password_wo` is given. This is only used when the VM cluster is registered.",
+	}
+	vmClusterSchema["key"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		RequiredWith: []string{"certificate"},
+		Description:  "The PEM encoded private key of `certificate`. This is only used when the VM cluster is registered.",
+	}
+	vmClusterSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The VM cluster name. This is computed if it's not set.",
+	}
+	vmClusterSchema["password"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{"password_wo"},
+		Description:   "The LXD trust password, used by MAAS to add its client certificate to the LXD trust store. This is stored in the Terraform state, consider using `password_wo` instead. This is only used when the VM cluster is registered.",
+	}
+	vmClusterSchema["password_wo"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		WriteOnly:     true,
+		ConflictsWith: []string{"password"},
+		RequiredWith:  []string{"password_wo_version"},
+		Description:   "Write-only LXD trust password, used by MAAS to add its client certificate to the LXD trust store. This is never stored in the Terraform state, and it can be set from an ephemeral value. Requires Terraform 1.11 or later. This is only used when the VM cluster is registered.",
+	}
+	vmClusterSchema["password_wo_version"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"password_wo"},
+		Description:  "An arbitrary version number of the `password_wo` value. Since the trust password is only used when the VM cluster is registered, changing it doesn't update an existing VM cluster.",
+	}
+	vmClusterSchema["pool"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The VM cluster pool name. This is computed if it's not set.",
+	}
+	vmClusterSchema["power_address"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Address of any member of the LXD cluster, which MAAS uses to discover the cluster. For example: `https://10.0.0.2:8443`. The address given here must reachable by the MAAS server.",
+	}
+	vmClusterSchema["project"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "The LXD project used by MAAS to manage the VMs of the cluster. This is computed if it's not set.",
+	}
+	vmClusterSchema["zone"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The VM cluster zone name. This is computed if it's not set.",
+	}
+
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS VM clusters, i.e. LXD clusters registered as a whole.",
+		CreateContext: resourceVMClusterCreate,
+		ReadContext:   resourceVMClusterRead,
+		UpdateContext: resourceVMClusterUpdate,
+		DeleteContext: resourceVMClusterDelete,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("password"), cty.GetAttrPath("password_wo")),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*ClientConfig).Client
+
+				cluster, err := getVMCluster(client, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", cluster.ID),
+					"project": cluster.Project,
+				}
+				// The power address of the cluster is the one of its members
+				if len(cluster.Hosts) > 0 {
+					vmHostParams, err := client.VMHost.GetParameters(cluster.Hosts[0].ID)
+					if err != nil {
+						return nil, err
+					}
+					tfState["power_address"] = vmHostParams["power_address"]
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: vmClusterSchema,
+	}
+}
+
+func resourceVMClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	// Register the LXD cluster member, MAAS registers all the cluster members and the VM cluster
+	vmHostParams := entity.VMHostParams{
+		Type:         "lxd",
+		PowerAddress: d.Get("power_address").(string),
+		Certificate:  d.Get("certificate").(string),
+		Key:          d.Get("key").(string),
+		Zone:         d.Get("zone").(string),
+		Pool:         d.Get("pool").(string),
+	}
+	password, err := getVMHostTrustPassword(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vmHost, err := createVMHost(client, &vmHostParams, d.Get("project").(string), password)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Find the VM cluster of the registered member
+	clusters, err := getVMClusters(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	idx := slices.IndexFunc(clusters, func(cluster vmCluster) bool {
+		return slices.ContainsFunc(cluster.Hosts, func(host vmClusterHost) bool { return host.ID == vmHost.ID })
+	})
+	if idx < 0 {
+		// The LXD server isn't part of a cluster, so it's removed to avoid leaving an unmanaged VM host
+		if err := client.VMHost.Delete(vmHost.ID); err != nil {
+			log.Printf("[WARN] Failed to delete VM host (%v): %s\n", vmHost.ID, err)
+		}
+		return diag.Errorf("LXD server (%s) is not a member of a LXD cluster", d.Get("power_address").(string))
+	}
+
+	// Save Id
+	d.SetId(fmt.Sprintf("%v", clusters[idx].ID))
+
+	// Return updated VM cluster
+	return resourceVMClusterUpdate(ctx, d, meta)
+}
+
+func resourceVMClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	// Get VM cluster details
+	cluster, err := getVMCluster(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vmHosts, err := getVMClusterHosts(client, cluster)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Set Terraform state
+	if err := setTerraformState(d, getVMClusterTFState(cluster, vmHosts)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVMClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	// Update VM cluster options
+	params := url.Values{}
+	for _, k := range []string{"name", "pool", "zone"} {
+		if v := d.Get(k).(string); v != "" {
+			params.Set(k, v)
+		}
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = apiClient.GetSubObject("vm-cluster").GetSubObject(d.Id()).Put(params, func(data []byte) error { return nil })
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceVMClusterRead(ctx, d, meta)
+}
+
+func resourceVMClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	// Delete VM cluster, MAAS removes its VM hosts
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vmClusterClient := apiClient.GetSubObject("vm-cluster").GetSubObject(d.Id())
+	if err := vmClusterClient.Delete(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getVMClusterTFSchema returns the schema of the attributes shared by the VM cluster resource and data source.
+func getVMClusterTFSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"hosts": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The VM hosts of the cluster members, sorted by name.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The VM host ID.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The VM host name.",
+					},
+					"resources_cores_available": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The VM host number of available CPU cores.",
+					},
+					"resources_local_storage_available": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The VM host available local storage (in bytes).",
+					},
+					"resources_memory_available": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The VM host available RAM memory (in MB).",
+					},
+				},
+			},
+		},
+		"resources_cores_available": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the available number of CPU cores of the cluster members.",
+		},
+		"resources_cores_total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the total number of CPU cores of the cluster members.",
+		},
+		"resources_cores_used": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the number of CPU cores used by the composed machines of the cluster members.",
+		},
+		"resources_local_storage_available": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the available local storage (in bytes) of the cluster members.",
+		},
+		"resources_local_storage_total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the total local storage (in bytes) of the cluster members.",
+		},
+		"resources_local_storage_used": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the local storage (in bytes) used by the composed machines of the cluster members.",
+		},
+		"resources_memory_available": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the available RAM memory (in MB) of the cluster members.",
+		},
+		"resources_memory_total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the total RAM memory (in MB) of the cluster members.",
+		},
+		"resources_memory_used": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The sum of the RAM memory (in MB) used by the composed machines of the cluster members.",
+		},
+		"vm_host_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The list of IDs of the VM hosts of the cluster members, sorted by name.",
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+	}
+}
+
+// getVMClusterTFState returns the state of the attributes shared by the VM cluster resource and data source.
+// The resources of the cluster are the sum of the resources of its members.
+func getVMClusterTFState(cluster *vmCluster, vmHosts []entity.VMHost) map[string]interface{} {
+	sorted := make([]entity.VMHost, len(vmHosts))
+	copy(sorted, vmHosts)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var total, used, available entity.VMHostResource
+	hosts := make([]map[string]interface{}, len(sorted))
+	vmHostIDs := make([]int, len(sorted))
+	for i, vmHost := range sorted {
+		vmHostIDs[i] = vmHost.ID
+		hosts[i] = map[string]interface{}{
+			"id":                                vmHost.ID,
+			"name":                              vmHost.Name,
+			"resources_cores_available":         vmHost.Available.Cores,
+			"resources_memory_available":        vmHost.Available.Memory,
+			"resources_local_storage_available": vmHost.Available.LocalStorage,
+		}
+		total.Cores += vmHost.Total.Cores
+		total.Memory += vmHost.Total.Memory
+		total.LocalStorage += vmHost.Total.LocalStorage
+		used.Cores += vmHost.Used.Cores
+		used.Memory += vmHost.Used.Memory
+		used.LocalStorage += vmHost.Used.LocalStorage
+		available.Cores += vmHost.Available.Cores
+		available.Memory += vmHost.Available.Memory
+		available.LocalStorage += vmHost.Available.LocalStorage
+	}
+
+	return map[string]interface{}{
+		"name":                              cluster.Name,
+		"project":                           cluster.Project,
+		"pool":                              string(cluster.Pool),
+		"zone":                              string(cluster.Zone),
+		"hosts":                             hosts,
+		"vm_host_ids":                       vmHostIDs,
+		"resources_cores_total":             total.Cores,
+		"resources_cores_used":              used.Cores,
+		"resources_cores_available":         available.Cores,
+		"resources_memory_total":            total.Memory,
+		"resources_memory_used":             used.Memory,
+		"resources_memory_available":        available.Memory,
+		"resources_local_storage_total":     total.LocalStorage,
+		"resources_local_storage_used":      used.LocalStorage,
+		"resources_local_storage_available": available.LocalStorage,
+	}
+}
+
+// getVMClusters returns the MAAS VM clusters. The VM clusters endpoint is not supported
+// by gomaasclient, so the MAAS API is called directly.
+func getVMClusters(client *client.Client) ([]vmCluster, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	clusters := []vmCluster{}
+	err = apiClient.GetSubObject("vm-clusters").Get("", url.Values{}, func(data []byte) error {
+		return json.Unmarshal(data, &clusters)
+	})
+	return clusters, err
+}
+
+func getVMCluster(client *client.Client, identifier string) (*vmCluster, error) {
+	clusters, err := getVMClusters(client)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		if strconv.Itoa(cluster.ID) == identifier || cluster.Name == identifier {
+			return &cluster, nil
+		}
+	}
+	return nil, fmt.Errorf("VM cluster (%s) was not found", identifier)
+}
+
+// getVMClusterHosts returns the VM hosts of the cluster members.
+func getVMClusterHosts(client *client.Client, cluster *vmCluster) ([]entity.VMHost, error) {
+	vmHosts, err := client.VMHosts.Get()
+	if err != nil {
+		return nil, err
+	}
+	result := []entity.VMHost{}
+	for _, vmHost := range vmHosts {
+		for _, host := range cluster.Hosts {
+			if host.ID == vmHost.ID {
+				result = append(result, vmHost)
+				break
+			}
+		}
+	}
+	return result, nil
+}
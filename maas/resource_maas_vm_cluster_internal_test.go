@@ -0,0 +1,116 @@
+package maas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMClusterUnmarshal(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+		out  vmCluster
+	}{
+		{
+			name: "objects",
+			data: `{"id": 1, "name": "cluster-01", "project": "maas", "pool": {"id": 0, "name": "default"}, "zone": {"id": 1, "name": "rack-01"}, "hosts": [{"id": 5, "name": "lxd-01"}]}`,
+			out:  vmCluster{ID: 1, Name: "cluster-01", Project: "maas", Pool: "default", Zone: "rack-01", Hosts: []vmClusterHost{{ID: 5, Name: "lxd-01"}}},
+		},
+		{
+			name: "names",
+			data: `{"id": 1, "name": "cluster-01", "project": "maas", "pool": "default", "zone": "rack-01", "hosts": []}`,
+			out:  vmCluster{ID: 1, Name: "cluster-01", Project: "maas", Pool: "default", Zone: "rack-01", Hosts: []vmClusterHost{}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var cluster vmCluster
+			assert.NoError(t, json.Unmarshal([]byte(testCase.data), &cluster))
+			assert.Equal(t, testCase.out, cluster)
+		})
+	}
+}
+
+func TestGetVMClusterTFState(t *testing.T) {
+	cluster := &vmCluster{ID: 1, Name: "cluster-01", Project: "maas", Pool: "default", Zone: "rack-01"}
+	vmHosts := []entity.VMHost{
+		{
+			ID:        6,
+			Name:      "lxd-02",
+			Total:     entity.VMHostResource{Cores: 16, Memory: 65536, LocalStorage: 1000},
+			Used:      entity.VMHostResource{Cores: 2, Memory: 4096, LocalStorage: 100},
+			Available: entity.VMHostResource{Cores: 14, Memory: 61440, LocalStorage: 900},
+		},
+		{
+			ID:        5,
+			Name:      "lxd-01",
+			Total:     entity.VMHostResource{Cores: 8, Memory: 32768, LocalStorage: 500},
+			Used:      entity.VMHostResource{Cores: 8, Memory: 32768, LocalStorage: 500},
+			Available: entity.VMHostResource{},
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"name":    "cluster-01",
+		"project": "maas",
+		"pool":    "default",
+		"zone":    "rack-01",
+		"hosts": []map[string]interface{}{
+			{"id": 5, "name": "lxd-01", "resources_cores_available": 0, "resources_memory_available": int64(0), "resources_local_storage_available": int64(0)},
+			{"id": 6, "name": "lxd-02", "resources_cores_available": 14, "resources_memory_available": int64(61440), "resources_local_storage_available": int64(900)},
+		},
+		"vm_host_ids":                       []int{5, 6},
+		"resources_cores_total":             24,
+		"resources_cores_used":              10,
+		"resources_cores_available":         14,
+		"resources_memory_total":            int64(98304),
+		"resources_memory_used":             int64(36864),
+		"resources_memory_available":        int64(61440),
+		"resources_local_storage_total":     int64(1500),
+		"resources_local_storage_used":      int64(600),
+		"resources_local_storage_available": int64(900),
+	}, getVMClusterTFState(cluster, vmHosts))
+}
+
+func TestGetVMClusterTrustPassword(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attributes map[string]*terraform.ResourceAttrDiff
+		rawConfig  map[string]cty.Value
+		password   string
+	}{
+		{
+			name: "password",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"power_address": {New: "https://10.113.1.30:8443"},
+				"password":      {New: "plain"},
+			},
+			rawConfig: map[string]cty.Value{"password_wo": cty.NullVal(cty.String)},
+			password:  "plain",
+		},
+		{
+			name: "password_wo",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"power_address":       {New: "https://10.113.1.30:8443"},
+				"password_wo_version": {New: "1"},
+			},
+			rawConfig: map[string]cty.Value{"password_wo": cty.StringVal("secret")},
+			password:  "secret",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := testApplyResourceData(t, resourceMaasVMCluster(), nil, testCase.attributes, testCase.rawConfig)
+			password, err := getVMHostTrustPassword(d)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.password, password)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package maas_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-maas/maas"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccMaasVMCluster(powerAddress string, password string, name string, zone string) string {
+	return fmt.Sprintf(`
+resource "maas_zone" "test" {
+  name = "%s"
+}
+
+resource "maas_vm_cluster" "test" {
+  power_address = "%s"
+  password      = "%s"
+  name          = "%s"
+  zone          = maas_zone.test.name
+}
+`, zone, powerAddress, password, name)
+}
+
+func TestAccResourceMaasVMCluster_basic(t *testing.T) {
+
+	// The address of a member of a LXD cluster, which isn't registered in MAAS yet.
+	powerAddress := os.Getenv("TF_ACC_VM_CLUSTER_POWER_ADDRESS")
+	// The LXD trust password of the cluster.
+	password := os.Getenv("TF_ACC_VM_CLUSTER_PASSWORD")
+	name := acctest.RandomWithPrefix("tf-vm-cluster")
+	zone := acctest.RandomWithPrefix("tf-zone")
+
+	checks := []resource.TestCheckFunc{
+		testAccMaasVMClusterCheckExists("maas_vm_cluster.test"),
+		resource.TestCheckResourceAttr("maas_vm_cluster.test", "power_address", powerAddress),
+		resource.TestCheckResourceAttrPair("maas_vm_cluster.test", "zone", "maas_zone.test", "name"),
+		resource.TestCheckResourceAttrSet("maas_vm_cluster.test", "project"),
+		resource.TestCheckResourceAttrSet("maas_vm_cluster.test", "pool"),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testutils.PreCheck(t, []string{"TF_ACC_VM_CLUSTER_POWER_ADDRESS", "TF_ACC_VM_CLUSTER_PASSWORD"})
+		},
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMaasVMClusterDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaasVMCluster(powerAddress, password, name, zone),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_vm_cluster.test", "name", name))...),
+			},
+			// Test update
+			{
+				Config: testAccMaasVMCluster(powerAddress, password, name+"-updated", zone),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_vm_cluster.test", "name", name+"-updated"))...),
+			},
+			// Test import
+			{
+				ResourceName:      "maas_vm_cluster.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The trust password is only used when the VM cluster is registered
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccMaasVMClusterCheckExists(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		// The VM cluster members are registered as VM hosts
+		vmHostIDs, err := testAccGetMaasVMClusterHostIDs(rs)
+		if err != nil {
+			return err
+		}
+		if len(vmHostIDs) == 0 {
+			return fmt.Errorf("MAAS VM cluster (%s) has no hosts", rs.Primary.ID)
+		}
+		conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+		for _, id := range vmHostIDs {
+			if _, err := conn.VMHost.Get(id); err != nil {
+				return fmt.Errorf("error getting VM cluster host: %s", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccGetMaasVMClusterHostIDs returns the IDs of the VM hosts of a maas_vm_cluster in the Terraform state.
+func testAccGetMaasVMClusterHostIDs(rs *terraform.ResourceState) ([]int, error) {
+	count, err := strconv.Atoi(rs.Primary.Attributes["hosts.#"])
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, count)
+	for i := range count {
+		ids[i], err = strconv.Atoi(rs.Primary.Attributes[fmt.Sprintf("hosts.%d.id", i)])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+func testAccCheckMaasVMClusterDestroy(s *terraform.State) error {
+	// retrieve the connection established in Provider configuration
+	conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+
+	// loop through the resources in state, verifying the VM hosts of each maas_vm_cluster
+	// are removed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "maas_vm_cluster" {
+			continue
+		}
+
+		vmHostIDs, err := testAccGetMaasVMClusterHostIDs(rs)
+		if err != nil {
+			return err
+		}
+		for _, id := range vmHostIDs {
+			response, err := conn.VMHost.Get(id)
+			if err == nil {
+				if response != nil && response.ID == id {
+					return fmt.Errorf("MAAS VM cluster (%s) host (%d) still exists.", rs.Primary.ID, id)
+				}
+
+				continue
+			}
+
+			// If the error is equivalent to 404 not found, the VM cluster host is removed.
+			// Otherwise return the error
+			if !strings.Contains(err.Error(), "404 Not Found") {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -2,15 +2,30 @@ package maas
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"log"
+	"math/big"
 	"net/http"
+	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/canonical/gomaasclient/client"
 	"github.com/canonical/gomaasclient/entity"
+	"github.com/google/go-querystring/query"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/juju/gomaasapi/v2"
@@ -21,6 +36,16 @@ var (
 		"machine",
 		"power_address",
 	}
+	// vmHostLXDArguments are the arguments only supported by the LXD VM hosts.
+	vmHostLXDArguments = []string{
+		"certificate",
+		"generate_certificate",
+		"key",
+		"password",
+		"password_wo",
+		"password_wo_version",
+		"project",
+	}
 )
 
 func resourceMaasVMHost() *schema.Resource {
@@ -30,6 +55,11 @@ func resourceMaasVMHost() *schema.Resource {
 		ReadContext:   resourceVMHostRead,
 		UpdateContext: resourceVMHostUpdate,
 		DeleteContext: resourceVMHostDelete,
+		CustomizeDiff: resourceVMHostCustomizeDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("power_pass"), cty.GetAttrPath("power_pass_wo")),
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("password"), cty.GetAttrPath("password_wo")),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				client := meta.(*ClientConfig).Client
@@ -39,8 +69,10 @@ func resourceMaasVMHost() *schema.Resource {
 					return nil, err
 				}
 				tfState := map[string]interface{}{
-					"id":   fmt.Sprintf("%v", vmHost.ID),
-					"type": vmHost.Type,
+					"id":                      fmt.Sprintf("%v", vmHost.ID),
+					"type":                    vmHost.Type,
+					"on_destroy":              "refuse",
+					"keep_machine_on_destroy": false,
 				}
 				if vmHost.Host.SystemID != "" {
 					tfState["machine"] = vmHost.Host.SystemID
@@ -63,6 +95,14 @@ func resourceMaasVMHost() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"certificate": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"machine", "generate_certificate"},
+				RequiredWith:  []string{"key"},
+				Description:   "The PEM encoded client certificate used by MAAS to connect to the LXD VM host. It must be in the LXD trust store, unless `password` or `password_wo` is given. It can only be set when `type` is `lxd`, and it can't be set if `machine` argument is used. This is computed if it's not set, and it can be added to the LXD trust store (e.g. with `lxc config trust add`).",
+			},
 			"cpu_over_commit_ratio": {
 				Type:        schema.TypeFloat,
 				Optional:    true,
@@ -75,6 +115,12 @@ func resourceMaasVMHost() *schema.Resource {
 				Computed:    true,
 				Description: "The new VM host default macvlan mode. Supported values are: `bridge`, `passthru`, `private`, `vepa`. This is computed if it's not set.",
 			},
+			"default_storage_pool": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name or ID of the default storage pool of the VM host, used by the composed machines storage disks without a pool. This is computed if it's not set.",
+			},
 			"deploy_params": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -110,13 +156,35 @@ func resourceMaasVMHost() *schema.Resource {
 					},
 				},
 			},
+			"generate_certificate": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"machine", "certificate", "key"},
+				Description:   "Boolean value indicating if the provider generates the client certificate and key used by MAAS to connect to the LXD VM host. The generated certificate is exposed with the `certificate` attribute. This is only used when the VM host is registered. It can only be set when `type` is `lxd`. Defaults to `false`.",
+			},
+			"key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"machine", "generate_certificate"},
+				RequiredWith:  []string{"certificate"},
+				Description:   "The PEM encoded private key of `certificate`. It can only be set when `type` is `lxd`, and it can't be set if `machine` argument is used. This is computed if `generate_certificate` is used. It's not read from MAAS unless it's set or generated, so that the client private key isn't copied into the Terraform state.",
+			},
+			"keep_machine_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the machine specified using `machine` is kept deployed after the VM host is destroyed. By default, the machine is released. Defaults to `false`.",
+			},
 			"machine": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
 				ExactlyOneOf:  vmHostSources,
-				ConflictsWith: []string{"power_address", "power_user", "power_pass"},
-				Description:   "The identifier (hostname, FQDN or system ID) of a registered ready MAAS machine. This is going to be deployed and registered as a new VM host. This argument conflicts with: `power_address`, `power_user`, `power_pass`.",
+				ConflictsWith: []string{"power_address", "power_user", "power_pass", "power_pass_wo"},
+				Description:   "The identifier (hostname, FQDN or system ID) of a registered ready MAAS machine. This is going to be deployed and registered as a new VM host. This argument conflicts with: `power_address`, `power_user`, `power_pass`, `power_pass_wo`.",
 			},
 			"memory_over_commit_ratio": {
 				Type:        schema.TypeFloat,
@@ -130,6 +198,35 @@ func resourceMaasVMHost() *schema.Resource {
 				Computed:    true,
 				Description: "The new VM host name. This is computed if it's not set.",
 			},
+			"on_destroy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "refuse",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"refuse", "decompose", "orphan"}, false)),
+				Description:      "What happens to the machines composed on the VM host when it's destroyed. Supported values are: `refuse` (the VM host isn't deleted while it has composed machines), `decompose` (the composed machines are deleted first), `orphan` (the VM host is deleted, and MAAS keeps the records of its composed machines). Defaults to `refuse`.",
+			},
+			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"machine", "password_wo"},
+				Description:   "The LXD trust password, used by MAAS to add its client certificate to the LXD trust store. This is stored in the Terraform state, consider using `password_wo` instead. This is only used when the VM host is registered. It can only be set when `type` is `lxd`, and it can't be set if `machine` argument is used.",
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"machine", "password"},
+				RequiredWith:  []string{"password_wo_version"},
+				Description:   "Write-only LXD trust password, used by MAAS to add its client certificate to the LXD trust store. This is never stored in the Terraform state, and it can be set from an ephemeral value. Requires Terraform 1.11 or later. This is only used when the VM host is registered. It can only be set when `type` is `lxd`, and it can't be set if `machine` argument is used.",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "An arbitrary version number of the `password_wo` value. Since the trust password is only used when the VM host is registered, changing it doesn't update an existing VM host.",
+			},
 			"pool": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -147,8 +244,23 @@ func resourceMaasVMHost() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"machine"},
-				Description:   "User password to use for power control of the VM host. Cannot be set if `machine` parameter is used.",
+				ConflictsWith: []string{"machine", "power_pass_wo"},
+				Description:   "User password to use for power control of the VM host. This is stored in the Terraform state, consider using `power_pass_wo` instead. Cannot be set if `machine` parameter is used.",
+			},
+			"power_pass_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"machine", "power_pass"},
+				RequiredWith:  []string{"power_pass_wo_version"},
+				Description:   "Write-only user password to use for power control of the VM host. This is never stored in the Terraform state, and it can be set from an ephemeral value. Requires Terraform 1.11 or later. The password is only sent to MAAS when the VM host is created, or when `power_pass_wo_version` changes. Cannot be set if `machine` parameter is used.",
+			},
+			"power_pass_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"power_pass_wo"},
+				Description:  "An arbitrary version number of the `power_pass_wo` value. Changing it updates the VM host password with the current `power_pass_wo` value.",
 			},
 			"power_user": {
 				Type:          schema.TypeString,
@@ -156,6 +268,19 @@ func resourceMaasVMHost() *schema.Resource {
 				ConflictsWith: []string{"machine"},
 				Description:   "User name to use for power control of the VM host. Cannot be set if `machine` parameter is used.",
 			},
+			"project": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"machine"},
+				Description:   "The LXD project used by MAAS to manage the VMs of the VM host. It can only be set when `type` is `lxd`, and it can't be set if `machine` argument is used. This is computed if it's not set.",
+			},
+			"refresh_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value. Changing it refreshes the VM host, so that MAAS discovers its hardware changes (e.g. new disks), and waits for its resources to be updated.",
+			},
 			"resources_cores_total": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -171,6 +296,7 @@ func resourceMaasVMHost() *schema.Resource {
 				Computed:    true,
 				Description: "The VM host total RAM memory (in MB).",
 			},
+			"storage_pools": getVMHostTFSchema()["storage_pools"],
 			"tags": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -196,6 +322,7 @@ func resourceMaasVMHost() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 	}
@@ -221,7 +348,32 @@ func resourceVMHostCreate(ctx context.Context, d *schema.ResourceData, meta inte
 			return diag.FromErr(err)
 		}
 	} else {
-		vmHost, err = client.VMHosts.Create(getVMHostParams(d))
+		// Generate the LXD client certificate, before it's used to register the VM host
+		if d.Get("generate_certificate").(bool) && d.Get("certificate").(string) == "" {
+			commonName := d.Get("name").(string)
+			if commonName == "" {
+				commonName = "maas"
+			}
+			certificate, key, err := generateVMHostCertificate(commonName)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err := setTerraformState(d, map[string]interface{}{"certificate": certificate, "key": key}); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		var vmHostParams *entity.VMHostParams
+		vmHostParams, err = getVMHostParamsWithWriteOnly(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// The storage pools are only known once MAAS discovers the VM host
+		vmHostParams.DefaultStoragePool = ""
+		password, err := getVMHostTrustPassword(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		vmHost, err = createVMHost(client, vmHostParams, d.Get("project").(string), password)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -259,6 +411,18 @@ func resourceVMHostRead(ctx context.Context, d *schema.ResourceData, meta interf
 		"resources_cores_total":         vmHost.Total.Cores,
 		"resources_memory_total":        vmHost.Total.Memory,
 		"resources_local_storage_total": vmHost.Total.LocalStorage,
+		"storage_pools":                 getVMHostStoragePoolsTFState(vmHost.StoragePools),
+		"default_storage_pool":          getVMHostDefaultStoragePoolTFState(vmHost.StoragePools, d.Get("default_storage_pool").(string)),
+	}
+	// The LXD VM hosts expose the project and the client certificate used by MAAS
+	if vmHost.Type == "lxd" {
+		vmHostParams, err := client.VMHost.GetParameters(vmHost.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for k, v := range getVMHostLXDParametersTFState(vmHostParams, d.Get("key").(string) != "") {
+			tfState[k] = v
+		}
 	}
 	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
@@ -277,11 +441,22 @@ func resourceVMHostUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	// Update VM host options
-	_, err = client.VMHost.Update(id, getVMHostParams(d))
+	vmHostParams, err := getVMHostParamsWithWriteOnly(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.VMHost.Update(id, vmHostParams)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// Refresh the VM host hardware
+	if !d.IsNewResource() && d.HasChange("refresh_trigger") {
+		if _, err := refreshVMHost(ctx, client, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceVMHostRead(ctx, d, meta)
 }
 
@@ -297,10 +472,43 @@ func resourceVMHostDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	// Handle the machines composed on the VM host
+	machines, err := client.Machines.Get(&entity.MachinesParams{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostedMachines := getVMHostHostedMachines(vmHost.ID, machines)
+	switch onDestroy := d.Get("on_destroy").(string); onDestroy {
+	case "orphan":
+		log.Printf("[DEBUG] Deleting VM host (%s) with %d composed machines\n", vmHost.Name, len(hostedMachines))
+	case "decompose":
+		for _, machine := range hostedMachines {
+			if err := client.Machine.Delete(machine.SystemID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err := waitForVMHostDecomposed(ctx, client, vmHost.ID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(err)
+		}
+	default:
+		// VM hosts created before on_destroy existed have no value in the state, and use the default
+		if len(hostedMachines) > 0 {
+			hostnames := make([]string, len(hostedMachines))
+			for i, machine := range hostedMachines {
+				hostnames[i] = machine.Hostname
+			}
+			return diag.Errorf("VM host (%s) has composed machines (%s). Set on_destroy to \"decompose\" to delete them, or to \"orphan\" to keep them", vmHost.Name, strings.Join(hostnames, ", "))
+		}
+	}
+
 	err = client.VMHost.Delete(vmHost.ID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if !isVMHostMachineReleasedOnDestroy(d) {
+		return nil
+	}
 
 	// Check if VM host was linked to a dynamic machine and if yes, return
 	// Dynamic machines are deleted by MAAS when their VM hosts are deleted.
@@ -326,6 +534,45 @@ func resourceVMHostDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+// getVMHostHostedMachines returns the machines composed on the VM host, sorted by hostname.
+func getVMHostHostedMachines(vmHostID int, machines []entity.Machine) []entity.Machine {
+	hostedMachines := []entity.Machine{}
+	for _, machine := range machines {
+		if machine.VMHost != nil && machine.VMHost.ID == vmHostID {
+			hostedMachines = append(hostedMachines, machine)
+		}
+	}
+	sort.Slice(hostedMachines, func(i, j int) bool {
+		return hostedMachines[i].Hostname < hostedMachines[j].Hostname
+	})
+	return hostedMachines
+}
+
+// waitForVMHostDecomposed waits until the VM host has no composed machines.
+func waitForVMHostDecomposed(ctx context.Context, client *client.Client, vmHostID int, maxTimeout time.Duration) error {
+	log.Printf("[DEBUG] Waiting for the machines of VM host (%v) to be deleted\n", vmHostID)
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"decomposing"},
+		Target:  []string{"decomposed"},
+		Refresh: func() (interface{}, string, error) {
+			machines, err := client.Machines.Get(&entity.MachinesParams{})
+			if err != nil {
+				return nil, "", err
+			}
+			hostedMachines := getVMHostHostedMachines(vmHostID, machines)
+			if len(hostedMachines) > 0 {
+				return hostedMachines, "decomposing", nil
+			}
+			return hostedMachines, "decomposed", nil
+		},
+		Timeout:    maxTimeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
 func getVMHostParams(d *schema.ResourceData) *entity.VMHostParams {
 	return &entity.VMHostParams{
 		Name:                  d.Get("name").(string),
@@ -333,6 +580,9 @@ func getVMHostParams(d *schema.ResourceData) *entity.VMHostParams {
 		PowerAddress:          d.Get("power_address").(string),
 		PowerUser:             d.Get("power_user").(string),
 		PowerPass:             d.Get("power_pass").(string),
+		DefaultStoragePool:    d.Get("default_storage_pool").(string),
+		Certificate:           d.Get("certificate").(string),
+		Key:                   d.Get("key").(string),
 		CPUOverCommitRatio:    d.Get("cpu_over_commit_ratio").(float64),
 		MemoryOverCommitRatio: d.Get("memory_over_commit_ratio").(float64),
 		DefaultMacvlanMode:    d.Get("default_macvlan_mode").(string),
@@ -342,6 +592,195 @@ func getVMHostParams(d *schema.ResourceData) *entity.VMHostParams {
 	}
 }
 
+// getVMHostParamsWithWriteOnly returns the VM host parameters, including the write-only
+// password when the VM host is created or when its version is changed. Otherwise, the
+// password is omitted and MAAS keeps the current one.
+func getVMHostParamsWithWriteOnly(d *schema.ResourceData) (*entity.VMHostParams, error) {
+	params := getVMHostParams(d)
+	if d.IsNewResource() || d.HasChange("power_pass_wo_version") {
+		powerPass, err := getWriteOnlyString(d, "power_pass_wo")
+		if err != nil {
+			return nil, err
+		}
+		if powerPass != "" {
+			params.PowerPass = powerPass
+		}
+	}
+	return params, nil
+}
+
+// isVMHostMachineReleasedOnDestroy reports whether the machine deployed as VM host is released
+// once the VM host is deleted. The VM hosts created before keep_machine_on_destroy existed have
+// no value in the state, so they keep releasing their machine.
+func isVMHostMachineReleasedOnDestroy(d *schema.ResourceData) bool {
+	return !d.Get("keep_machine_on_destroy").(bool)
+}
+
+// getVMHostTrustPassword returns the LXD trust password used to register the VM host or the VM cluster,
+// given either with the password argument or with the write-only one.
+func getVMHostTrustPassword(d *schema.ResourceData) (string, error) {
+	if password := d.Get("password").(string); password != "" {
+		return password, nil
+	}
+	return getWriteOnlyString(d, "password_wo")
+}
+
+// getVMHostLXDParametersTFState returns the LXD VM host parameters kept in the Terraform state.
+// The client private key is only kept if it's already known, given by the user or generated
+// by the provider, so that it isn't copied into the state otherwise.
+func getVMHostLXDParametersTFState(params map[string]string, withKey bool) map[string]interface{} {
+	keys := []string{"project", "certificate"}
+	if withKey {
+		keys = append(keys, "key")
+	}
+	tfState := map[string]interface{}{}
+	for _, k := range keys {
+		if val, ok := params[k]; ok {
+			tfState[k] = val
+		}
+	}
+	return tfState
+}
+
+// resourceVMHostCustomizeDiff checks that the LXD arguments aren't used for other VM host types.
+func resourceVMHostCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.GetAttr("type").IsKnown() {
+		return nil
+	}
+	configured := []string{}
+	for _, k := range vmHostLXDArguments {
+		if v := config.GetAttr(k); !v.IsNull() && !(v.IsKnown() && v.Type().Equals(cty.Bool) && v.False()) {
+			configured = append(configured, k)
+		}
+	}
+	return validateVMHostTypeArguments(d.Get("type").(string), configured)
+}
+
+// validateVMHostTypeArguments returns an error if any of the configured arguments isn't supported by the VM host type.
+func validateVMHostTypeArguments(vmHostType string, configured []string) error {
+	if vmHostType == "lxd" {
+		return nil
+	}
+	unsupported := []string{}
+	for _, k := range configured {
+		if slices.Contains(vmHostLXDArguments, k) {
+			unsupported = append(unsupported, k)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("the arguments (%s) can only be set when type is lxd", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// createVMHost registers a new VM host. The VM hosts endpoint is called directly, since gomaasclient
+// doesn't support the LXD project and trust password parameters.
+func createVMHost(client *client.Client, params *entity.VMHostParams, project string, password string) (*entity.VMHost, error) {
+	qsp, err := query.Values(params)
+	if err != nil {
+		return nil, err
+	}
+	if project != "" {
+		qsp.Set("project", project)
+	}
+	if password != "" {
+		qsp.Set("password", password)
+	}
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	vmHost := new(entity.VMHost)
+	err = apiClient.GetSubObject("pods").Post("", qsp, func(data []byte) error {
+		return json.Unmarshal(data, vmHost)
+	})
+	return vmHost, err
+}
+
+// generateVMHostCertificate returns a new self-signed client certificate and its private key, PEM encoded,
+// which MAAS can use to connect to a LXD VM host. Like the LXD client certificates, it uses an ECDSA P-384 key.
+func generateVMHostCertificate(commonName string) (string, string, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", err
+	}
+	notBefore := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"MAAS"}, CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return "", "", err
+	}
+	key, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return "", "", err
+	}
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})
+	return string(certificatePEM), string(keyPEM), nil
+}
+
+// getVMHostDefaultStoragePoolTFState returns the name of the default storage pool, or the current
+// value if it's the ID of the default storage pool.
+func getVMHostDefaultStoragePoolTFState(storagePools []entity.VMHostStoragePool, current string) string {
+	for _, storagePool := range storagePools {
+		if storagePool.Default {
+			if current == storagePool.ID {
+				return current
+			}
+			return storagePool.Name
+		}
+	}
+	return ""
+}
+
+// refreshVMHost refreshes the VM host, and waits until its resources are stable,
+// since MAAS may still update them after the refresh returns.
+func refreshVMHost(ctx context.Context, client *client.Client, id int, maxTimeout time.Duration) (*entity.VMHost, error) {
+	log.Printf("[DEBUG] Refreshing VM host (%v)\n", id)
+	previous, err := client.VMHost.Refresh(id)
+	if err != nil {
+		return nil, err
+	}
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"refreshing"},
+		Target:  []string{"refreshed"},
+		Refresh: func() (interface{}, string, error) {
+			vmHost, err := client.VMHost.Get(id)
+			if err != nil {
+				return nil, "", err
+			}
+			state := "refreshing"
+			if reflect.DeepEqual(vmHost.Total, previous.Total) && reflect.DeepEqual(vmHost.StoragePools, previous.StoragePools) {
+				state = "refreshed"
+			}
+			previous = vmHost
+			return vmHost, state, nil
+		},
+		Timeout:                   maxTimeout,
+		Delay:                     5 * time.Second,
+		MinTimeout:                3 * time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*entity.VMHost), nil
+}
+
 func deployMachineAsVMHost(ctx context.Context, client *client.Client, machineIdentifier string, maxTimeout time.Duration, deployParams *entity.MachineDeployParams) (*entity.VMHost, error) {
 	// Find machine
 	machine, err := getMachine(client, machineIdentifier)
@@ -0,0 +1,300 @@
+package maas
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVMHostTypeArguments(t *testing.T) {
+	testCases := []struct {
+		name       string
+		vmHostType string
+		configured []string
+		err        string
+	}{
+		{
+			name:       "lxd",
+			vmHostType: "lxd",
+			configured: []string{"certificate", "key", "password", "project"},
+		},
+		{
+			name:       "virsh without LXD arguments",
+			vmHostType: "virsh",
+			configured: []string{},
+		},
+		{
+			name:       "virsh with LXD arguments",
+			vmHostType: "virsh",
+			configured: []string{"generate_certificate", "project"},
+			err:        "the arguments (generate_certificate, project) can only be set when type is lxd",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateVMHostTypeArguments(testCase.vmHostType, testCase.configured)
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerateVMHostCertificate(t *testing.T) {
+	certificate, key, err := generateVMHostCertificate("lxd-host-01")
+	assert.NoError(t, err)
+
+	keyPair, err := tls.X509KeyPair([]byte(certificate), []byte(key))
+	assert.NoError(t, err)
+	parsed, err := x509.ParseCertificate(keyPair.Certificate[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "lxd-host-01", parsed.Subject.CommonName)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, parsed.ExtKeyUsage)
+}
+
+func TestGetVMHostDefaultStoragePoolTFState(t *testing.T) {
+	storagePools := []entity.VMHostStoragePool{
+		{ID: "pool-fast", Name: "fast"},
+		{ID: "pool-default", Name: "default", Default: true},
+	}
+
+	testCases := []struct {
+		name         string
+		storagePools []entity.VMHostStoragePool
+		current      string
+		out          string
+	}{
+		{
+			name:         "name",
+			storagePools: storagePools,
+			out:          "default",
+		},
+		{
+			name:         "ID is kept",
+			storagePools: storagePools,
+			current:      "pool-default",
+			out:          "pool-default",
+		},
+		{
+			name:         "drift",
+			storagePools: storagePools,
+			current:      "pool-fast",
+			out:          "default",
+		},
+		{
+			name: "no storage pools",
+			out:  "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, getVMHostDefaultStoragePoolTFState(testCase.storagePools, testCase.current))
+		})
+	}
+}
+
+func TestGetVMHostHostedMachines(t *testing.T) {
+	machines := []entity.Machine{
+		{SystemID: "abc123", Hostname: "vm-02", VMHost: &entity.MachineVMHost{ID: 1}},
+		{SystemID: "def456", Hostname: "host-01"},
+		{SystemID: "ghi789", Hostname: "vm-01", VMHost: &entity.MachineVMHost{ID: 1}},
+		{SystemID: "jkl012", Hostname: "vm-03", VMHost: &entity.MachineVMHost{ID: 2}},
+	}
+
+	hostedMachines := getVMHostHostedMachines(1, machines)
+	systemIDs := make([]string, len(hostedMachines))
+	for i, machine := range hostedMachines {
+		systemIDs[i] = machine.SystemID
+	}
+	assert.Equal(t, []string{"ghi789", "abc123"}, systemIDs)
+	assert.Empty(t, getVMHostHostedMachines(3, machines))
+}
+
+func TestGetVMHostParamsWithWriteOnly(t *testing.T) {
+	state := map[string]string{
+		"type":                  "virsh",
+		"power_address":         "qemu+ssh://ubuntu@10.0.0.10/system",
+		"power_pass_wo_version": "1",
+	}
+	testCases := []struct {
+		name       string
+		state      map[string]string
+		attributes map[string]*terraform.ResourceAttrDiff
+		rawConfig  map[string]cty.Value
+		powerPass  string
+	}{
+		{
+			name: "create with power_pass",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"type":          {New: "virsh"},
+				"power_address": {New: "qemu+ssh://ubuntu@10.0.0.10/system"},
+				"power_pass":    {New: "plain"},
+			},
+			rawConfig: map[string]cty.Value{"power_pass_wo": cty.NullVal(cty.String)},
+			powerPass: "plain",
+		},
+		{
+			name: "create with power_pass_wo",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"type":                  {New: "virsh"},
+				"power_address":         {New: "qemu+ssh://ubuntu@10.0.0.10/system"},
+				"power_pass_wo_version": {New: "1"},
+			},
+			rawConfig: map[string]cty.Value{"power_pass_wo": cty.StringVal("secret")},
+			powerPass: "secret",
+		},
+		{
+			name:  "update with the same version",
+			state: state,
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"zone": {Old: "default", New: "rack-01"},
+			},
+			rawConfig: map[string]cty.Value{"power_pass_wo": cty.StringVal("rotated")},
+			powerPass: "",
+		},
+		{
+			name:  "update with a new version",
+			state: state,
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"power_pass_wo_version": {Old: "1", New: "2"},
+			},
+			rawConfig: map[string]cty.Value{"power_pass_wo": cty.StringVal("rotated")},
+			powerPass: "rotated",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := testApplyResourceData(t, resourceMaasVMHost(), testCase.state, testCase.attributes, testCase.rawConfig)
+			params, err := getVMHostParamsWithWriteOnly(d)
+			assert.NoError(t, err)
+			assert.Equal(t, "qemu+ssh://ubuntu@10.0.0.10/system", params.PowerAddress)
+			assert.Equal(t, testCase.powerPass, params.PowerPass)
+		})
+	}
+}
+
+func TestGetVMHostTrustPassword(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attributes map[string]*terraform.ResourceAttrDiff
+		rawConfig  map[string]cty.Value
+		password   string
+	}{
+		{
+			name: "password",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"type":     {New: "lxd"},
+				"password": {New: "plain"},
+			},
+			rawConfig: map[string]cty.Value{"password_wo": cty.NullVal(cty.String)},
+			password:  "plain",
+		},
+		{
+			name: "password_wo",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"type":                {New: "lxd"},
+				"password_wo_version": {New: "1"},
+			},
+			rawConfig: map[string]cty.Value{"password_wo": cty.StringVal("secret")},
+			password:  "secret",
+		},
+		{
+			name: "no password",
+			attributes: map[string]*terraform.ResourceAttrDiff{
+				"type": {New: "lxd"},
+			},
+			rawConfig: map[string]cty.Value{"password_wo": cty.NullVal(cty.String)},
+			password:  "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := testApplyResourceData(t, resourceMaasVMHost(), nil, testCase.attributes, testCase.rawConfig)
+			password, err := getVMHostTrustPassword(d)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.password, password)
+		})
+	}
+}
+
+func TestGetVMHostLXDParametersTFState(t *testing.T) {
+	params := map[string]string{
+		"power_address": "https://10.113.1.25:8443",
+		"project":       "maas",
+		"certificate":   "client-certificate",
+		"key":           "client-key",
+	}
+
+	testCases := []struct {
+		name    string
+		params  map[string]string
+		withKey bool
+		out     map[string]interface{}
+	}{
+		{
+			name:    "known key",
+			params:  params,
+			withKey: true,
+			out:     map[string]interface{}{"project": "maas", "certificate": "client-certificate", "key": "client-key"},
+		},
+		{
+			name:   "unknown key",
+			params: params,
+			out:    map[string]interface{}{"project": "maas", "certificate": "client-certificate"},
+		},
+		{
+			name:    "no parameters",
+			params:  map[string]string{},
+			withKey: true,
+			out:     map[string]interface{}{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, getVMHostLXDParametersTFState(testCase.params, testCase.withKey))
+		})
+	}
+}
+
+func TestIsVMHostMachineReleasedOnDestroy(t *testing.T) {
+	testCases := []struct {
+		name  string
+		state map[string]string
+		out   bool
+	}{
+		{
+			name:  "state without keep_machine_on_destroy",
+			state: map[string]string{"type": "lxd", "machine": "abc123"},
+			out:   true,
+		},
+		{
+			name:  "release the machine",
+			state: map[string]string{"type": "lxd", "machine": "abc123", "keep_machine_on_destroy": "false"},
+			out:   true,
+		},
+		{
+			name:  "keep the machine",
+			state: map[string]string{"type": "lxd", "machine": "abc123", "keep_machine_on_destroy": "true"},
+			out:   false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := resourceMaasVMHost().Data(&terraform.InstanceState{ID: "1", Attributes: testCase.state})
+			assert.Equal(t, testCase.out, isVMHostMachineReleasedOnDestroy(d))
+		})
+	}
+}
@@ -2,13 +2,30 @@ package maas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/canonical/gomaasclient/client"
 	"github.com/canonical/gomaasclient/entity"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	// vmHostMachineInterfaceTypes are the supported attachments of the VM host machine network interfaces.
+	vmHostMachineInterfaceTypes = []string{"bridge", "macvlan", "sriov"}
+	// vmHostMachineInterfaceMacvlanModes are the supported modes of the macvlan network interfaces.
+	vmHostMachineInterfaceMacvlanModes = []string{"bridge", "passthru", "private", "vepa"}
 )
 
 func resourceMaasVMHostMachine() *schema.Resource {
@@ -18,6 +35,15 @@ func resourceMaasVMHostMachine() *schema.Resource {
 		ReadContext:   resourceVMHostMachineRead,
 		UpdateContext: resourceVMHostMachineUpdate,
 		DeleteContext: resourceVMHostMachineDelete,
+		CustomizeDiff: resourceVMHostMachineCustomizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceMaasVMHostMachineResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceMaasVMHostMachineStateUpgradeV0,
+				Version: 0,
+			},
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				client := meta.(*ClientConfig).Client
@@ -26,7 +52,7 @@ func resourceMaasVMHostMachine() *schema.Resource {
 				if err != nil {
 					return nil, err
 				}
-				if machine.VMHost.ID == 0 || machine.VMHost.Name == "" || machine.VMHost.ResourceURI == "" {
+				if !isVMHostMachine(machine) {
 					return nil, fmt.Errorf("machine (%s) is not a VM host machine", d.Id())
 				}
 				tfState := map[string]interface{}{
@@ -44,11 +70,34 @@ func resourceMaasVMHostMachine() *schema.Resource {
 		UseJSONNumber: true,
 
 		Schema: map[string]*schema.Schema{
+			"architecture": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The architecture of the VM host machine (e.g. `amd64/generic`). It must be supported by the VM host. This is computed if it's not set.",
+			},
+			"capacity_check": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "error",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"error", "warn", "none"}, false)),
+				Description:      "How the VM host capacity is checked at plan time, before composing the machine. The requested cores, memory and storage disks are compared with the VM host free resources, taking into account its overcommit ratios and the free space of its storage pools. Supported values are: `error` (the plan fails), `warn` (the plan shows the problems in `capacity_problems`), `none` (no check is done). Each machine is checked on its own, so several machines planned together may still exhaust the VM host. Defaults to `error`.",
+			},
+			"capacity_problems": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The VM host capacity problems found at plan time when the machine is composed, by VM host name. This is empty if the machine fits, if the plan doesn't compose the machine, or if `capacity_check` is `none`. It can only be non-empty if `capacity_check` is `warn`, since the plan fails otherwise.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"cores": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The number of CPU cores (defaults to 1).",
+				Description: "The number of CPU cores (defaults to 1). This is computed if it's not set.",
 			},
 			"domain": {
 				Type:        schema.TypeString,
@@ -62,17 +111,26 @@ func resourceMaasVMHostMachine() *schema.Resource {
 				Computed:    true,
 				Description: "The VM host machine hostname. This is computed if it's not set.",
 			},
+			"hugepages_backed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Boolean value indicating if the VM host machine memory is backed by hugepages. Defaults to `false`.",
+			},
 			"memory": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The VM host machine RAM memory, specified in MB (defaults to 2048).",
+				Description: "The VM host machine RAM memory, specified in MB (defaults to 2048). This is computed if it's not set.",
 			},
 			"network_interfaces": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "A list of network interfaces for new the VM host. This argument only works when the VM host is deployed from a registered MAAS machine. Parameters defined below. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html).",
+				Description: "A list of network interfaces for new the VM host. This argument only works when the VM host is deployed from a registered MAAS machine. Parameters defined below. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html). This is computed if it's not set. The optional parameters that are not set are not compared with the composed machine, since MAAS chooses their value.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"fabric": {
@@ -81,19 +139,38 @@ func resourceMaasVMHostMachine() *schema.Resource {
 							Description: "The fabric for the network interface.",
 						},
 						"ip_address": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Static IP configured on the new network interface.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+							Description:      "Static IP configured on the new network interface.",
+						},
+						"mode": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(vmHostMachineInterfaceMacvlanModes, false)),
+							Description:      "The macvlan mode of the network interface. Supported values are: `bridge`, `passthru`, `private`, `vepa`. This can only be set when `type` is `macvlan`.",
 						},
 						"name": {
 							Type:        schema.TypeString,
 							Required:    true,
 							Description: "The network interface name.",
 						},
-						"subnet_cidr": {
+						"space": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "The subnet CIDR for the network interface.",
+							Description: "The space for the network interface.",
+						},
+						"subnet_cidr": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsCIDR),
+							Description:      "The subnet CIDR for the network interface.",
+						},
+						"type": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(vmHostMachineInterfaceTypes, false)),
+							Description:      "How the network interface is attached to the VM host. Supported values are: `bridge`, `macvlan`, `sriov`. This is chosen by MAAS if it's not set.",
 						},
 						"vlan": {
 							Type:        schema.TypeString,
@@ -104,10 +181,14 @@ func resourceMaasVMHostMachine() *schema.Resource {
 				},
 			},
 			"pinned_cores": {
-				Type:        schema.TypeInt,
+				Type:        schema.TypeList,
 				Optional:    true,
 				ForceNew:    true,
 				Description: "List of host CPU cores to pin the VM host machine to. If this is passed, the `cores` parameter is ignored.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
 			},
 			"pool": {
 				Type:        schema.TypeString,
@@ -118,8 +199,9 @@ func resourceMaasVMHostMachine() *schema.Resource {
 			"storage_disks": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "A list of storage disks for the new VM host. Parameters defined below. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html).",
+				Description: "A list of storage disks for the new VM host. Parameters defined below. This argument is processed in [attribute-as-blocks mode](https://www.terraform.io/docs/configuration/attr-as-blocks.html). This is computed if it's not set.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"pool": {
@@ -132,14 +214,31 @@ func resourceMaasVMHostMachine() *schema.Resource {
 							Required:    true,
 							Description: "The storage disk size, specified in GB.",
 						},
+						"tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "A list of tags the VM host storage pool of the disk must have.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 					},
 				},
 			},
+			"vm_cluster": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"vm_cluster", "vm_host"},
+				Description:  "ID or name of the VM cluster used to compose the new machine. The machine is composed on the cluster member with the most available memory among the ones with enough capacity, and `vm_host` is set to this member.",
+			},
 			"vm_host": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "ID or name of the VM host used to compose the new machine.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"vm_cluster", "vm_host"},
+				Description:  "ID or name of the VM host used to compose the new machine. This is computed if `vm_cluster` is set.",
 			},
 			"zone": {
 				Type:        schema.TypeString,
@@ -157,10 +256,22 @@ func resourceMaasVMHostMachine() *schema.Resource {
 func resourceVMHostMachineCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ClientConfig).Client
 
-	// Find VM host
-	vmHost, err := getVMHost(client, d.Get("vm_host").(string))
-	if err != nil {
-		return diag.FromErr(err)
+	// Find VM host, or the VM cluster member used to compose the machine
+	var vmHost *entity.VMHost
+	var err error
+	if vmClusterIdentifier := d.Get("vm_cluster").(string); vmClusterIdentifier != "" {
+		vmHost, _, err = selectVMClusterHost(client, vmClusterIdentifier, getVMHostMachineRequest(d.Get("cores").(int), d.Get("pinned_cores").([]interface{}), d.Get("memory").(int), d.Get("storage_disks").([]interface{})), "", nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("vm_host", fmt.Sprintf("%v", vmHost.ID)); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		vmHost, err = getVMHost(client, d.Get("vm_host").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	// Create VM host machine
@@ -168,7 +279,7 @@ func resourceVMHostMachineCreate(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	machine, err := client.VMHost.Compose(vmHost.ID, params)
+	machine, err := composeVMHostMachine(client, vmHost.ID, params)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -194,13 +305,36 @@ func resourceVMHostMachineRead(ctx context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if !isVMHostMachine(machine) {
+		return diag.Errorf("machine (%s) is not a VM host machine", d.Id())
+	}
+	vmHost, err := client.VMHost.Get(machine.VMHost.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Set Terraform state
 	tfState := map[string]interface{}{
-		"hostname": machine.Hostname,
-		"domain":   machine.Domain.Name,
-		"zone":     machine.Zone.Name,
-		"pool":     machine.Pool.Name,
+		"hostname":           machine.Hostname,
+		"domain":             machine.Domain.Name,
+		"zone":               machine.Zone.Name,
+		"pool":               machine.Pool.Name,
+		"memory":             machine.Memory,
+		"storage_disks":      getVMHostMachineStorageDisksTFState(machine.BlockDeviceSet, vmHost.StoragePools, d.Get("storage_disks").([]interface{})),
+		"network_interfaces": getVMHostMachineNetworkInterfacesTFState(machine.InterfaceSet, d.Get("network_interfaces").([]interface{})),
+	}
+	// The architecture can be given without its subarchitecture
+	if architecture := d.Get("architecture").(string); architecture != machine.Architecture && !strings.HasPrefix(machine.Architecture, architecture+"/") {
+		tfState["architecture"] = machine.Architecture
+	}
+	// The VM host can be given by ID or name
+	if vmHostIdentifier := d.Get("vm_host").(string); vmHostIdentifier != vmHost.Name && vmHostIdentifier != fmt.Sprintf("%v", vmHost.ID) {
+		tfState["vm_host"] = fmt.Sprintf("%v", vmHost.ID)
+	}
+	// MAAS doesn't expose the pinned cores of a composed machine, so the CPU count
+	// can only be compared with the number of cores when they aren't pinned
+	if len(d.Get("pinned_cores").([]interface{})) == 0 {
+		tfState["cores"] = machine.CPUCount
 	}
 	if err := setTerraformState(d, tfState); err != nil {
 		return diag.FromErr(err)
@@ -232,20 +366,56 @@ func resourceVMHostMachineDelete(ctx context.Context, d *schema.ResourceData, me
 	return nil
 }
 
-func getVMHostMachineParams(d *schema.ResourceData) (*entity.VMHostMachineParams, error) {
-	networkInterfaces, err := getVMHostMachineNetworkInterfaces(d.Get("network_interfaces").([]interface{}))
+// composeVMHostMachine composes a new machine on the VM host. The compose operation is called directly,
+// since gomaasclient supports neither a list of pinned cores nor the zone and pool parameters.
+func composeVMHostMachine(client *client.Client, vmHostID int, params url.Values) (*entity.Machine, error) {
+	apiClient, err := getAPIClient(client)
 	if err != nil {
 		return nil, err
 	}
-	params := entity.VMHostMachineParams{
-		Hostname:    d.Get("hostname").(string),
-		Cores:       d.Get("cores").(int),
-		PinnedCores: d.Get("pinned_cores").(int),
-		Memory:      int64(d.Get("memory").(int)),
-		Interfaces:  networkInterfaces,
-		Storage:     getVMHostMachineStorageDisks(d.Get("storage_disks").([]interface{})),
+	machine := new(entity.Machine)
+	err = apiClient.GetSubObject("pods").GetSubObject(fmt.Sprintf("%v", vmHostID)).Post("compose", params, func(data []byte) error {
+		return json.Unmarshal(data, machine)
+	})
+	return machine, err
+}
+
+func getVMHostMachineParams(d *schema.ResourceData) (url.Values, error) {
+	networkInterfaces, err := encodeVMHostMachineNetworkInterfaces(d.Get("network_interfaces").([]interface{}))
+	if err != nil {
+		return nil, err
 	}
-	return &params, nil
+	storageDisks, err := encodeVMHostMachineStorageDisks(d.Get("storage_disks").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	for k, v := range map[string]string{
+		"architecture": d.Get("architecture").(string),
+		"hostname":     d.Get("hostname").(string),
+		"domain":       d.Get("domain").(string),
+		"zone":         d.Get("zone").(string),
+		"pool":         d.Get("pool").(string),
+		"interfaces":   networkInterfaces,
+		"storage":      storageDisks,
+	} {
+		if v != "" {
+			params.Set(k, v)
+		}
+	}
+	if cores := d.Get("cores").(int); cores > 0 {
+		params.Set("cores", strconv.Itoa(cores))
+	}
+	if memory := d.Get("memory").(int); memory > 0 {
+		params.Set("memory", strconv.Itoa(memory))
+	}
+	for _, core := range d.Get("pinned_cores").([]interface{}) {
+		params.Add("pinned_cores", strconv.Itoa(core.(int)))
+	}
+	if d.Get("hugepages_backed").(bool) {
+		params.Set("hugepages_backed", "true")
+	}
+	return params, nil
 }
 
 func getVMHostMachineUpdateParams(d *schema.ResourceData) *entity.MachineParams {
@@ -257,43 +427,238 @@ func getVMHostMachineUpdateParams(d *schema.ResourceData) *entity.MachineParams
 	}
 }
 
-func getVMHostMachineNetworkInterfaces(networkInterfaces []interface{}) (string, error) {
+// encodeVMHostMachineNetworkInterfaces returns the network interface constraints in the MAAS compose
+// format, i.e. "name:key=value,key=value;name:key=value".
+func encodeVMHostMachineNetworkInterfaces(networkInterfaces []interface{}) (string, error) {
 	vmHostNetworkInterfaces := []string{}
+	names := []string{}
 	for _, networkInterface := range networkInterfaces {
 		n := networkInterface.(map[string]interface{})
-		vlan := n["vlan"].(string)
-		subnet := n["subnet_cidr"].(string)
-		ip := n["ip_address"].(string)
-		if vlan == "" && subnet == "" && ip == "" {
-			return "", fmt.Errorf("at least one of the network interface properties (vlan, subnet_cidr, ip_address) is required")
+		name := n["name"].(string)
+		if name == "" {
+			return "", fmt.Errorf("the network interface name is required")
 		}
-		properties := []string{}
-		if fabric := n["fabric"].(string); fabric != "" {
-			properties = append(properties, fmt.Sprintf("fabric=%s", fabric))
+		if err := validateVMHostMachineConstraintValue("network interface name", name, ":;,="); err != nil {
+			return "", err
+		}
+		if slices.Contains(names, name) {
+			return "", fmt.Errorf("network interface (%s) is defined more than once", name)
+		}
+		names = append(names, name)
+
+		if n["vlan"].(string) == "" && n["subnet_cidr"].(string) == "" && n["ip_address"].(string) == "" && n["space"].(string) == "" {
+			return "", fmt.Errorf("at least one of the network interface properties (vlan, subnet_cidr, ip_address, space) is required for network interface (%s)", name)
+		}
+		if ip := n["ip_address"].(string); ip != "" && net.ParseIP(ip) == nil {
+			return "", fmt.Errorf("invalid IP address (%s) for network interface (%s)", ip, name)
 		}
-		if vlan != "" {
-			properties = append(properties, fmt.Sprintf("vlan=%s", vlan))
+		if cidr := n["subnet_cidr"].(string); cidr != "" {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return "", fmt.Errorf("invalid subnet CIDR (%s) for network interface (%s)", cidr, name)
+			}
 		}
-		if subnet != "" {
-			properties = append(properties, fmt.Sprintf("subnet_cidr=%s", subnet))
+		interfaceType := n["type"].(string)
+		if interfaceType != "" && !slices.Contains(vmHostMachineInterfaceTypes, interfaceType) {
+			return "", fmt.Errorf("invalid type (%s) for network interface (%s)", interfaceType, name)
 		}
-		if ip != "" {
-			properties = append(properties, fmt.Sprintf("ip=%s", ip))
+		if mode := n["mode"].(string); mode != "" {
+			if interfaceType != "macvlan" {
+				return "", fmt.Errorf("the mode of network interface (%s) can only be set when its type is macvlan", name)
+			}
+			if !slices.Contains(vmHostMachineInterfaceMacvlanModes, mode) {
+				return "", fmt.Errorf("invalid macvlan mode (%s) for network interface (%s)", mode, name)
+			}
 		}
-		vmHostNetworkInterfaces = append(vmHostNetworkInterfaces, fmt.Sprintf("%s:%s", n["name"].(string), strings.Join(properties, ",")))
+
+		// The properties are encoded in a stable order, with the MAAS constraint keys
+		properties := []string{}
+		for _, property := range [][2]string{
+			{"fabric", "fabric"},
+			{"vlan", "vlan"},
+			{"space", "space"},
+			{"subnet_cidr", "subnet_cidr"},
+			{"ip_address", "ip"},
+			{"type", "type"},
+			{"mode", "mode"},
+		} {
+			value := n[property[0]].(string)
+			if value == "" {
+				continue
+			}
+			if err := validateVMHostMachineConstraintValue(fmt.Sprintf("%s of network interface (%s)", property[0], name), value, ";,="); err != nil {
+				return "", err
+			}
+			properties = append(properties, fmt.Sprintf("%s=%s", property[1], value))
+		}
+		vmHostNetworkInterfaces = append(vmHostNetworkInterfaces, fmt.Sprintf("%s:%s", name, strings.Join(properties, ",")))
 	}
 	return strings.Join(vmHostNetworkInterfaces, ";"), nil
 }
 
-func getVMHostMachineStorageDisks(storageDisks []interface{}) string {
+// encodeVMHostMachineStorageDisks returns the storage constraints in the MAAS compose format,
+// i.e. "label:size(pool,tag,tag),label:size". MAAS selects the storage pool matching the pool name and tags.
+func encodeVMHostMachineStorageDisks(storageDisks []interface{}) (string, error) {
 	vmHostStorageDisks := []string{}
 	for i, storageDisk := range storageDisks {
 		d := storageDisk.(map[string]interface{})
 		disk := fmt.Sprintf("disk%d:%d", i, int64(d["size_gigabytes"].(int)))
+		selectors := []string{}
 		if pool := d["pool"].(string); pool != "" {
-			disk = fmt.Sprintf("%s(%s)", disk, pool)
+			selectors = append(selectors, pool)
+		}
+		for _, tag := range d["tags"].([]interface{}) {
+			if tag == nil || tag.(string) == "" {
+				return "", fmt.Errorf("empty tag for storage disk (%d)", i)
+			}
+			selectors = append(selectors, tag.(string))
+		}
+		for _, selector := range selectors {
+			if err := validateVMHostMachineConstraintValue(fmt.Sprintf("pool or tag of storage disk (%d)", i), selector, ":,()"); err != nil {
+				return "", err
+			}
+		}
+		if len(selectors) > 0 {
+			disk = fmt.Sprintf("%s(%s)", disk, strings.Join(selectors, ","))
 		}
 		vmHostStorageDisks = append(vmHostStorageDisks, disk)
 	}
-	return strings.Join(vmHostStorageDisks, ",")
+	return strings.Join(vmHostStorageDisks, ","), nil
+}
+
+// validateVMHostMachineConstraintValue checks that a compose constraint value doesn't contain whitespace nor
+// the given separators of the MAAS constraint format, which would change the meaning of the constraints.
+func validateVMHostMachineConstraintValue(field string, value string, separators string) error {
+	if strings.ContainsAny(value, separators) || strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("invalid %s (%s): it must not contain whitespace nor any of %q", field, value, separators)
+	}
+	return nil
+}
+
+// getVMHostMachineStorageDisksTFState returns the storage disks of a composed machine, ordered by creation.
+// The storage pools are given by name, except when the current state uses the default pool implicitly.
+func getVMHostMachineStorageDisksTFState(blockDevices []entity.BlockDevice, storagePools []entity.VMHostStoragePool, current []interface{}) []map[string]interface{} {
+	disks := []entity.BlockDevice{}
+	for _, blockDevice := range blockDevices {
+		if blockDevice.Type == "physical" {
+			disks = append(disks, blockDevice)
+		}
+	}
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].ID < disks[j].ID
+	})
+
+	storageDisks := make([]map[string]interface{}, len(disks))
+	for i, disk := range disks {
+		pool := disk.StoragePool
+		isDefault := false
+		for _, storagePool := range storagePools {
+			if storagePool.ID == disk.StoragePool || storagePool.Name == disk.StoragePool {
+				pool = storagePool.Name
+				isDefault = storagePool.Default
+				break
+			}
+		}
+		if i < len(current) && current[i] != nil && current[i].(map[string]interface{})["pool"].(string) == "" && isDefault {
+			pool = ""
+		}
+		// MAAS composes the disks with decimal gigabytes, and the reported size may be slightly rounded
+		// The storage pool tags are only used to select the pool, and are kept from the current state
+		tags := []interface{}{}
+		if i < len(current) && current[i] != nil {
+			tags = current[i].(map[string]interface{})["tags"].([]interface{})
+		}
+		storageDisks[i] = map[string]interface{}{
+			"size_gigabytes": int(math.Round(float64(disk.Size) / (1000 * 1000 * 1000))),
+			"pool":           pool,
+			"tags":           tags,
+		}
+	}
+	return storageDisks
+}
+
+// getVMHostMachineNetworkInterfacesTFState returns the network interfaces of a composed machine.
+// The interfaces of the current state come first, followed by the other interfaces ordered by creation.
+func getVMHostMachineNetworkInterfacesTFState(networkInterfaces []entity.NetworkInterface, current []interface{}) []map[string]interface{} {
+	sorted := make([]entity.NetworkInterface, len(networkInterfaces))
+	copy(sorted, networkInterfaces)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	currentByName := map[string]map[string]interface{}{}
+	names := []string{}
+	for _, c := range current {
+		if c == nil {
+			continue
+		}
+		n := c.(map[string]interface{})
+		currentByName[n["name"].(string)] = n
+		names = append(names, n["name"].(string))
+	}
+	for _, networkInterface := range sorted {
+		if _, ok := currentByName[networkInterface.Name]; !ok {
+			names = append(names, networkInterface.Name)
+		}
+	}
+
+	result := []map[string]interface{}{}
+	for _, name := range names {
+		idx := slices.IndexFunc(sorted, func(n entity.NetworkInterface) bool { return n.Name == name })
+		if idx < 0 {
+			continue
+		}
+		networkInterface := sorted[idx]
+		c, known := currentByName[name]
+		if !known {
+			c = map[string]interface{}{"fabric": "", "vlan": "", "space": "", "subnet_cidr": "", "ip_address": "", "type": "", "mode": ""}
+		}
+		vlan := networkInterface.VLAN
+		space := vlan.Space
+		if space == "undefined" {
+			space = ""
+		}
+		subnetCIDRs := []string{}
+		ipAddresses := []string{}
+		staticIPAddress := ""
+		for _, link := range networkInterface.Links {
+			if link.Subnet.CIDR != "" {
+				subnetCIDRs = append(subnetCIDRs, link.Subnet.CIDR)
+			}
+			if link.IPAddress != "" {
+				ipAddresses = append(ipAddresses, link.IPAddress)
+				if strings.EqualFold(link.Mode, "static") && staticIPAddress == "" {
+					staticIPAddress = link.IPAddress
+				}
+			}
+		}
+		firstSubnetCIDR := ""
+		if len(subnetCIDRs) > 0 {
+			firstSubnetCIDR = subnetCIDRs[0]
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":        name,
+			"fabric":      getVMHostMachineInterfaceValue(c["fabric"].(string), known, []string{vlan.Fabric, fmt.Sprintf("%v", vlan.FabricID)}, fmt.Sprintf("%v", vlan.FabricID)),
+			"vlan":        getVMHostMachineInterfaceValue(c["vlan"].(string), known, []string{vlan.Name, fmt.Sprintf("%v", vlan.ID), fmt.Sprintf("%v", vlan.VID)}, fmt.Sprintf("%v", vlan.ID)),
+			"subnet_cidr": getVMHostMachineInterfaceValue(c["subnet_cidr"].(string), known, subnetCIDRs, firstSubnetCIDR),
+			"ip_address":  getVMHostMachineInterfaceValue(c["ip_address"].(string), known, ipAddresses, staticIPAddress),
+			"space":       getVMHostMachineInterfaceValue(c["space"].(string), known, []string{space}, space),
+			// MAAS doesn't expose how the network interfaces are attached to the VM host
+			"type": c["type"].(string),
+			"mode": c["mode"].(string),
+		})
+	}
+	return result
+}
+
+// getVMHostMachineInterfaceValue returns the value of a network interface property. The properties
+// unset in the current state are kept unset, since MAAS chooses their value, and the set ones are kept
+// as long as they match one of the representations (e.g. name or ID) of the actual value.
+func getVMHostMachineInterfaceValue(current string, known bool, accepted []string, actual string) string {
+	if known && current == "" {
+		return ""
+	}
+	if current != "" && slices.Contains(accepted, current) {
+		return current
+	}
+	return actual
 }
@@ -0,0 +1,304 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Defaults used by MAAS when composing a machine without the given resources.
+	defaultVMHostMachineCores            = 1
+	defaultVMHostMachineMemory           = 2048
+	defaultVMHostMachineStorageGigabytes = 8
+)
+
+// vmHostMachineReplaceArguments are the arguments that replace the VM host machine when they change.
+var vmHostMachineReplaceArguments = []string{"architecture", "cores", "hugepages_backed", "memory", "network_interfaces", "pinned_cores", "storage_disks", "vm_cluster"}
+
+// vmHostMachineRequest contains the resources requested for a new VM host machine.
+// Storage is given in bytes per storage pool name, where an empty name means the default pool.
+type vmHostMachineRequest struct {
+	Cores   int
+	Pinned  bool
+	Memory  int64
+	Storage map[string]int64
+}
+
+func resourceVMHostMachineCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The VM cluster member of a replaced machine is selected again when it's composed
+	if d.Id() != "" && d.Get("vm_cluster").(string) != "" && d.HasChanges(vmHostMachineReplaceArguments...) {
+		if err := d.SetNewComputed("vm_host"); err != nil {
+			return err
+		}
+	}
+
+	// The capacity problems of a previous plan are cleared when the capacity isn't checked
+	mode := d.Get("capacity_check").(string)
+	if mode == "none" {
+		return d.SetNew("capacity_problems", []string{})
+	}
+	// Only new machines, or machines being replaced, request resources from the VM host
+	if d.Id() != "" && !d.HasChanges("vm_cluster", "vm_host", "cores", "pinned_cores", "memory", "storage_disks") {
+		return d.SetNew("capacity_problems", []string{})
+	}
+	// The computed arguments that are not set use the MAAS defaults, or the current values if the machine is replaced
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+	for _, k := range []string{"vm_cluster", "vm_host", "cores", "pinned_cores", "memory", "storage_disks"} {
+		if !config.GetAttr(k).IsWhollyKnown() {
+			log.Printf("[DEBUG] Skipping the VM host capacity check since %s is not known yet\n", k)
+			return d.SetNew("capacity_problems", []string{})
+		}
+	}
+
+	client := meta.(*ClientConfig).Client
+	request := getVMHostMachineRequest(d.Get("cores").(int), d.Get("pinned_cores").([]interface{}), d.Get("memory").(int), d.Get("storage_disks").([]interface{}))
+	problems := []string{}
+	var message string
+	if vmClusterIdentifier := d.Get("vm_cluster").(string); vmClusterIdentifier != "" {
+		// The resources of a replaced machine are released on the member it's composed on
+		var replacedVMHost string
+		var replacedRequest *vmHostMachineRequest
+		if d.Id() != "" {
+			replacedVMHost, replacedRequest = getVMHostMachineReplacedRequest(d)
+		}
+		vmHost, membersProblems, err := selectVMClusterHost(client, vmClusterIdentifier, request, replacedVMHost, replacedRequest)
+		if err != nil {
+			return err
+		}
+		if membersProblems[vmHost.Name] != nil {
+			members := make([]string, 0, len(membersProblems))
+			for name := range membersProblems {
+				members = append(members, name)
+			}
+			sort.Strings(members)
+			for _, name := range members {
+				problems = append(problems, fmt.Sprintf("%s: %s", name, strings.Join(membersProblems[name], ", ")))
+			}
+			message = fmt.Sprintf("the machine cannot be composed on any member of VM cluster (%s): %s", vmClusterIdentifier, strings.Join(problems, "; "))
+		}
+	} else {
+		vmHost, err := getVMHost(client, d.Get("vm_host").(string))
+		if err != nil {
+			return err
+		}
+
+		// The resources of a replaced machine are released before the new one is composed
+		if d.Id() != "" {
+			if replacedVMHost, replacedRequest := getVMHostMachineReplacedRequest(d); isVMHostIdentifier(vmHost, replacedVMHost) {
+				vmHost = releaseVMHostMachineRequest(vmHost, replacedRequest)
+			}
+		}
+
+		if vmHostProblems := getVMHostCapacityProblems(vmHost, request); len(vmHostProblems) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s", vmHost.Name, strings.Join(vmHostProblems, ", ")))
+			message = fmt.Sprintf("the machine cannot be composed on VM host (%s): %s", vmHost.Name, strings.Join(vmHostProblems, "; "))
+		}
+	}
+	// Warnings cannot be returned at plan time, so the capacity problems are shown in the plan instead
+	if len(problems) == 0 || mode == "warn" {
+		return d.SetNew("capacity_problems", problems)
+	}
+	return fmt.Errorf("%s. Set capacity_check to \"warn\" or \"none\" to skip this check", message)
+}
+
+// getVMHostMachineReplacedRequest returns the VM host of a replaced machine, and the resources it requested.
+func getVMHostMachineReplacedRequest(d *schema.ResourceDiff) (string, *vmHostMachineRequest) {
+	oldVMHost, _ := d.GetChange("vm_host")
+	oldCores, _ := d.GetChange("cores")
+	oldPinnedCores, _ := d.GetChange("pinned_cores")
+	oldMemory, _ := d.GetChange("memory")
+	oldStorageDisks, _ := d.GetChange("storage_disks")
+	return oldVMHost.(string), getVMHostMachineRequest(oldCores.(int), oldPinnedCores.([]interface{}), oldMemory.(int), oldStorageDisks.([]interface{}))
+}
+
+// isVMHostIdentifier reports whether the identifier is the ID or the name of the VM host.
+func isVMHostIdentifier(vmHost *entity.VMHost, identifier string) bool {
+	return identifier == fmt.Sprintf("%v", vmHost.ID) || identifier == vmHost.Name
+}
+
+// releaseVMClusterHostMachineRequest returns a copy of the VM cluster members, with the resources of
+// the request released on the member given by its identifier.
+func releaseVMClusterHostMachineRequest(vmHosts []entity.VMHost, vmHostIdentifier string, request *vmHostMachineRequest) []entity.VMHost {
+	released := make([]entity.VMHost, len(vmHosts))
+	for i := range vmHosts {
+		if isVMHostIdentifier(&vmHosts[i], vmHostIdentifier) {
+			released[i] = *releaseVMHostMachineRequest(&vmHosts[i], request)
+		} else {
+			released[i] = vmHosts[i]
+		}
+	}
+	return released
+}
+
+// selectVMClusterHost returns the VM cluster member used to compose a machine, and the capacity problems of
+// the members that can't compose it. The resources of a replaced machine, if any, are released on its member first.
+func selectVMClusterHost(client *client.Client, vmClusterIdentifier string, request *vmHostMachineRequest, replacedVMHost string, replacedRequest *vmHostMachineRequest) (*entity.VMHost, map[string][]string, error) {
+	cluster, err := getVMCluster(client, vmClusterIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	vmHosts, err := getVMClusterHosts(client, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(vmHosts) == 0 {
+		return nil, nil, fmt.Errorf("VM cluster (%s) has no members", cluster.Name)
+	}
+	if replacedRequest != nil {
+		vmHosts = releaseVMClusterHostMachineRequest(vmHosts, replacedVMHost, replacedRequest)
+	}
+	vmHost, problems := selectVMHostMachineHost(vmHosts, request)
+	return vmHost, problems, nil
+}
+
+// selectVMHostMachineHost returns the VM host with the most available memory among the ones where the
+// request fits, or among all the VM hosts if it doesn't fit anywhere. The capacity problems of the VM hosts
+// where the request doesn't fit are returned by VM host name.
+func selectVMHostMachineHost(vmHosts []entity.VMHost, request *vmHostMachineRequest) (*entity.VMHost, map[string][]string) {
+	problems := map[string][]string{}
+	var selected *entity.VMHost
+	var selectedFits bool
+	var selectedMemory int64
+	for i, vmHost := range vmHosts {
+		hostProblems := getVMHostCapacityProblems(&vmHost, request)
+		fits := len(hostProblems) == 0
+		if !fits {
+			problems[vmHost.Name] = hostProblems
+		}
+		memoryRatio := vmHost.MemoryOverCommitRatio
+		if memoryRatio == 0 {
+			memoryRatio = 1
+		}
+		availableMemory := int64(math.Floor(float64(vmHost.Total.Memory)*memoryRatio)) - vmHost.Used.Memory
+		if selected == nil || (fits && !selectedFits) || (fits == selectedFits && availableMemory > selectedMemory) {
+			selected = &vmHosts[i]
+			selectedFits = fits
+			selectedMemory = availableMemory
+		}
+	}
+	return selected, problems
+}
+
+// getVMHostMachineRequest returns the resources requested by the VM host machine arguments, using the MAAS defaults.
+func getVMHostMachineRequest(cores int, pinnedCores []interface{}, memory int, storageDisks []interface{}) *vmHostMachineRequest {
+	request := &vmHostMachineRequest{
+		Cores:   cores,
+		Memory:  int64(memory),
+		Storage: map[string]int64{},
+	}
+	if len(pinnedCores) > 0 {
+		request.Cores = len(pinnedCores)
+		request.Pinned = true
+	}
+	if request.Cores == 0 {
+		request.Cores = defaultVMHostMachineCores
+	}
+	if request.Memory == 0 {
+		request.Memory = defaultVMHostMachineMemory
+	}
+	// MAAS converts the composed disk sizes with decimal gigabytes
+	for _, storageDisk := range storageDisks {
+		disk := storageDisk.(map[string]interface{})
+		request.Storage[disk["pool"].(string)] += int64(disk["size_gigabytes"].(int)) * 1000 * 1000 * 1000
+	}
+	if len(storageDisks) == 0 {
+		request.Storage[""] = defaultVMHostMachineStorageGigabytes * 1000 * 1000 * 1000
+	}
+	return request
+}
+
+// releaseVMHostMachineRequest returns a copy of the VM host, with the resources of the request released.
+func releaseVMHostMachineRequest(vmHost *entity.VMHost, request *vmHostMachineRequest) *entity.VMHost {
+	released := *vmHost
+	released.Used.Cores -= request.Cores
+	released.Used.Memory -= request.Memory
+	released.StoragePools = make([]entity.VMHostStoragePool, len(vmHost.StoragePools))
+	copy(released.StoragePools, vmHost.StoragePools)
+	for pool, size := range request.Storage {
+		for i, storagePool := range released.StoragePools {
+			if storagePool.Name == pool || (pool == "" && storagePool.Default) {
+				released.StoragePools[i].Used -= size
+			}
+		}
+		released.Used.LocalStorage -= size
+	}
+	return &released
+}
+
+// getVMHostCapacityProblems returns the reasons why the request doesn't fit on the VM host, if any.
+// The CPU and memory capacities take into account the VM host overcommit ratios,
+// while the pinned cores and the storage cannot be overcommitted.
+func getVMHostCapacityProblems(vmHost *entity.VMHost, request *vmHostMachineRequest) []string {
+	problems := []string{}
+
+	cpuRatio := vmHost.CPUOverCommitRatio
+	if request.Pinned || cpuRatio == 0 {
+		cpuRatio = 1
+	}
+	if availableCores := int(math.Floor(float64(vmHost.Total.Cores)*cpuRatio)) - vmHost.Used.Cores; request.Cores > availableCores {
+		problems = append(problems, fmt.Sprintf("%d CPU cores requested, %d available", request.Cores, max(availableCores, 0)))
+	}
+	memoryRatio := vmHost.MemoryOverCommitRatio
+	if memoryRatio == 0 {
+		memoryRatio = 1
+	}
+	if availableMemory := int64(math.Floor(float64(vmHost.Total.Memory)*memoryRatio)) - vmHost.Used.Memory; request.Memory > availableMemory {
+		problems = append(problems, fmt.Sprintf("%d MB of memory requested, %d MB available", request.Memory, max(availableMemory, 0)))
+	}
+
+	if len(vmHost.StoragePools) == 0 {
+		// VM hosts without storage pools only report their local storage
+		var size int64
+		for _, poolSize := range request.Storage {
+			size += poolSize
+		}
+		if available := vmHost.Total.LocalStorage - vmHost.Used.LocalStorage; size > available {
+			problems = append(problems, fmt.Sprintf("%d bytes of storage requested, %d bytes available", size, max(available, 0)))
+		}
+		return problems
+	}
+	storage := map[string]int64{}
+	for pool, size := range request.Storage {
+		if pool == "" {
+			for _, p := range vmHost.StoragePools {
+				if p.Default {
+					pool = p.Name
+				}
+			}
+			if pool == "" {
+				problems = append(problems, "the VM host has no default storage pool")
+				continue
+			}
+		}
+		storage[pool] += size
+	}
+	pools := make([]string, 0, len(storage))
+	for pool := range storage {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	for _, pool := range pools {
+		idx := slices.IndexFunc(vmHost.StoragePools, func(p entity.VMHostStoragePool) bool { return p.Name == pool })
+		if idx < 0 {
+			problems = append(problems, fmt.Sprintf("storage pool (%s) was not found", pool))
+			continue
+		}
+		storagePool := vmHost.StoragePools[idx]
+		if available := storagePool.Total - storagePool.Used; storage[pool] > available {
+			problems = append(problems, fmt.Sprintf("%d bytes of storage requested from storage pool (%s), %d bytes available", storage[pool], pool, max(available, 0)))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,128 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMaasVMHostMachineResourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"cores": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"hostname": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"memory": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"network_interfaces": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fabric": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"subnet_cidr": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"vlan": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"pinned_cores": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"pool": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"storage_disks": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pool": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"size_gigabytes": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"vm_host": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMaasVMHostMachineStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	// Convert pinned_cores from a single core to a list of cores. MAAS composed the machines
+	// with the given core pinned, while the zero value wasn't sent.
+	pinnedCores := []interface{}{}
+	switch v := rawState["pinned_cores"].(type) {
+	case json.Number:
+		core, err := v.Int64()
+		if err != nil {
+			return nil, err
+		}
+		if core > 0 {
+			pinnedCores = append(pinnedCores, int(core))
+		}
+	case float64:
+		if v > 0 {
+			pinnedCores = append(pinnedCores, int(v))
+		}
+	case int:
+		if v > 0 {
+			pinnedCores = append(pinnedCores, v)
+		}
+	}
+	rawState["pinned_cores"] = pinnedCores
+
+	return rawState, nil
+}
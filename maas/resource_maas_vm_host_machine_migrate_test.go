@@ -0,0 +1,59 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceMaasVMHostMachineResourceV0(t *testing.T) {
+	// The V0 schema is the one of the released provider, before pinned_cores became a list
+	v0 := resourceMaasVMHostMachineResourceV0().Schema
+	keys := make([]string, 0, len(v0))
+	for k := range v0 {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	expected := []string{"cores", "domain", "hostname", "memory", "network_interfaces", "pinned_cores", "pool", "storage_disks", "vm_host", "zone"}
+	if !reflect.DeepEqual(expected, keys) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, keys)
+	}
+	if v0["pinned_cores"].Type != schema.TypeInt {
+		t.Fatalf("expected pinned_cores to be an integer, got %s", v0["pinned_cores"].Type)
+	}
+	for _, k := range []string{"cores", "memory", "network_interfaces", "storage_disks"} {
+		if v0[k].Computed {
+			t.Fatalf("expected %s not to be computed", k)
+		}
+	}
+}
+
+func TestResourceMaasVMHostMachineInstanceStateUpgradeV0(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		name        string
+		pinnedCores interface{}
+		expected    []interface{}
+	}{
+		{name: "pinned core", pinnedCores: json.Number("3"), expected: []interface{}{3}},
+		{name: "no pinned core", pinnedCores: json.Number("0"), expected: []interface{}{}},
+		{name: "unset", pinnedCores: nil, expected: []interface{}{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := resourceMaasVMHostMachineStateUpgradeV0(ctx, map[string]interface{}{"pinned_cores": testCase.pinnedCores}, nil)
+			if err != nil {
+				t.Fatalf("error migrating state: %s", err)
+			}
+
+			if !reflect.DeepEqual(testCase.expected, actual["pinned_cores"]) {
+				t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", testCase.expected, actual["pinned_cores"])
+			}
+		})
+	}
+}
@@ -0,0 +1,482 @@
+package maas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVMHostMachineRequest(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cores        int
+		pinnedCores  []interface{}
+		memory       int
+		storageDisks []interface{}
+		out          *vmHostMachineRequest
+	}{
+		{
+			name: "defaults",
+			out:  &vmHostMachineRequest{Cores: 1, Memory: 2048, Storage: map[string]int64{"": 8000000000}},
+		},
+		{
+			name:        "pinned cores",
+			cores:       4,
+			pinnedCores: []interface{}{2, 3},
+			memory:      4096,
+			storageDisks: []interface{}{
+				map[string]interface{}{"size_gigabytes": 10, "pool": "", "tags": []interface{}{}},
+				map[string]interface{}{"size_gigabytes": 20, "pool": "fast", "tags": []interface{}{"ssd"}},
+				map[string]interface{}{"size_gigabytes": 5, "pool": "fast", "tags": []interface{}{}},
+			},
+			out: &vmHostMachineRequest{Cores: 2, Pinned: true, Memory: 4096, Storage: map[string]int64{"": 10000000000, "fast": 25000000000}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, getVMHostMachineRequest(testCase.cores, testCase.pinnedCores, testCase.memory, testCase.storageDisks))
+		})
+	}
+}
+
+func TestGetVMHostCapacityProblems(t *testing.T) {
+	vmHost := &entity.VMHost{
+		Name: "kvm-host-01",
+		StoragePools: []entity.VMHostStoragePool{
+			{Name: "default", Total: 100000000000, Used: 90000000000, Default: true},
+			{Name: "fast", Total: 50000000000, Used: 0},
+		},
+		Total:                 entity.VMHostResource{Cores: 8, Memory: 16384},
+		Used:                  entity.VMHostResource{Cores: 14, Memory: 8192},
+		CPUOverCommitRatio:    2,
+		MemoryOverCommitRatio: 1,
+	}
+
+	testCases := []struct {
+		name     string
+		vmHost   *entity.VMHost
+		request  *vmHostMachineRequest
+		problems []string
+	}{
+		{
+			name:     "fits with overcommit",
+			vmHost:   vmHost,
+			request:  &vmHostMachineRequest{Cores: 2, Memory: 8192, Storage: map[string]int64{"": 10000000000, "fast": 50000000000}},
+			problems: []string{},
+		},
+		{
+			name:    "pinned cores are not overcommitted",
+			vmHost:  vmHost,
+			request: &vmHostMachineRequest{Cores: 2, Pinned: true, Memory: 1024, Storage: map[string]int64{}},
+			problems: []string{
+				"2 CPU cores requested, 0 available",
+			},
+		},
+		{
+			name:    "default pool is merged with its name",
+			vmHost:  vmHost,
+			request: &vmHostMachineRequest{Cores: 3, Memory: 8193, Storage: map[string]int64{"": 6000000000, "default": 6000000000, "slow": 1}},
+			problems: []string{
+				"3 CPU cores requested, 2 available",
+				"8193 MB of memory requested, 8192 MB available",
+				"12000000000 bytes of storage requested from storage pool (default), 10000000000 bytes available",
+				"storage pool (slow) was not found",
+			},
+		},
+		{
+			name: "local storage without storage pools",
+			vmHost: &entity.VMHost{
+				Total: entity.VMHostResource{Cores: 4, Memory: 4096, LocalStorage: 10000000000},
+				Used:  entity.VMHostResource{LocalStorage: 5000000000},
+			},
+			request: &vmHostMachineRequest{Cores: 1, Memory: 2048, Storage: map[string]int64{"": 8000000000}},
+			problems: []string{
+				"8000000000 bytes of storage requested, 5000000000 bytes available",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.problems, getVMHostCapacityProblems(testCase.vmHost, testCase.request))
+		})
+	}
+}
+
+func TestReleaseVMHostMachineRequest(t *testing.T) {
+	vmHost := &entity.VMHost{
+		StoragePools: []entity.VMHostStoragePool{
+			{Name: "default", Total: 100, Used: 60, Default: true},
+		},
+		Used: entity.VMHostResource{Cores: 4, Memory: 4096, LocalStorage: 60},
+	}
+	released := releaseVMHostMachineRequest(vmHost, &vmHostMachineRequest{Cores: 2, Memory: 2048, Storage: map[string]int64{"": 40}})
+
+	assert.Equal(t, entity.VMHostResource{Cores: 2, Memory: 2048, LocalStorage: 20}, released.Used)
+	assert.Equal(t, int64(20), released.StoragePools[0].Used)
+	// The original VM host is left untouched
+	assert.Equal(t, int64(60), vmHost.StoragePools[0].Used)
+	assert.Equal(t, 4, vmHost.Used.Cores)
+}
+
+func TestGetVMHostMachineStorageDisksTFState(t *testing.T) {
+	blockDevices := []entity.BlockDevice{
+		{ID: 12, Type: "physical", Size: 20000000000, StoragePool: "pool-fast"},
+		{ID: 10, Type: "physical", Size: 15000001024, StoragePool: "pool-default"},
+		{ID: 13, Type: "virtual", Size: 20000000000},
+	}
+	storagePools := []entity.VMHostStoragePool{
+		{ID: "pool-default", Name: "default", Default: true},
+		{ID: "pool-fast", Name: "fast"},
+	}
+
+	testCases := []struct {
+		name    string
+		current []interface{}
+		out     []map[string]interface{}
+	}{
+		{
+			name: "import",
+			out: []map[string]interface{}{
+				{"size_gigabytes": 15, "pool": "default", "tags": []interface{}{}},
+				{"size_gigabytes": 20, "pool": "fast", "tags": []interface{}{}},
+			},
+		},
+		{
+			name: "implicit default pool",
+			current: []interface{}{
+				map[string]interface{}{"size_gigabytes": 15, "pool": "", "tags": []interface{}{}},
+				map[string]interface{}{"size_gigabytes": 20, "pool": "", "tags": []interface{}{"ssd"}},
+			},
+			out: []map[string]interface{}{
+				{"size_gigabytes": 15, "pool": "", "tags": []interface{}{}},
+				{"size_gigabytes": 20, "pool": "fast", "tags": []interface{}{"ssd"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, getVMHostMachineStorageDisksTFState(blockDevices, storagePools, testCase.current))
+		})
+	}
+}
+
+func TestGetVMHostMachineNetworkInterfacesTFState(t *testing.T) {
+	networkInterfaces := []entity.NetworkInterface{
+		{
+			ID:   21,
+			Name: "eth1",
+			VLAN: entity.VLAN{ID: 5002, VID: 100, Name: "storage", Fabric: "fabric-1", FabricID: 1, Space: "storage"},
+			Links: []entity.NetworkInterfaceLink{
+				{Mode: "auto", IPAddress: "10.0.100.20", Subnet: entity.Subnet{CIDR: "10.0.100.0/24"}},
+			},
+		},
+		{
+			ID:   20,
+			Name: "eth0",
+			VLAN: entity.VLAN{ID: 5001, VID: 0, Name: "untagged", Fabric: "fabric-0", FabricID: 0, Space: "undefined"},
+			Links: []entity.NetworkInterfaceLink{
+				{Mode: "static", IPAddress: "10.0.0.20", Subnet: entity.Subnet{CIDR: "10.0.0.0/24"}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		current []interface{}
+		out     []map[string]interface{}
+	}{
+		{
+			name: "import",
+			out: []map[string]interface{}{
+				{"name": "eth0", "fabric": "0", "vlan": "5001", "space": "", "subnet_cidr": "10.0.0.0/24", "ip_address": "10.0.0.20", "type": "", "mode": ""},
+				{"name": "eth1", "fabric": "1", "vlan": "5002", "space": "storage", "subnet_cidr": "10.0.100.0/24", "ip_address": "", "type": "", "mode": ""},
+			},
+		},
+		{
+			name: "configured values are kept",
+			current: []interface{}{
+				map[string]interface{}{"name": "eth1", "fabric": "fabric-1", "vlan": "100", "space": "storage", "subnet_cidr": "", "ip_address": "", "type": "macvlan", "mode": "bridge"},
+				map[string]interface{}{"name": "eth0", "fabric": "", "vlan": "", "space": "", "subnet_cidr": "10.0.0.0/24", "ip_address": "10.0.0.20", "type": "", "mode": ""},
+			},
+			out: []map[string]interface{}{
+				{"name": "eth1", "fabric": "fabric-1", "vlan": "100", "space": "storage", "subnet_cidr": "", "ip_address": "", "type": "macvlan", "mode": "bridge"},
+				{"name": "eth0", "fabric": "", "vlan": "", "space": "", "subnet_cidr": "10.0.0.0/24", "ip_address": "10.0.0.20", "type": "", "mode": ""},
+			},
+		},
+		{
+			name: "drift is detected",
+			current: []interface{}{
+				map[string]interface{}{"name": "eth0", "fabric": "fabric-2", "vlan": "200", "space": "", "subnet_cidr": "10.0.200.0/24", "ip_address": "10.0.200.20", "type": "sriov", "mode": ""},
+				map[string]interface{}{"name": "eth2", "fabric": "", "vlan": "", "space": "", "subnet_cidr": "10.0.100.0/24", "ip_address": "", "type": "", "mode": ""},
+			},
+			out: []map[string]interface{}{
+				{"name": "eth0", "fabric": "0", "vlan": "5001", "space": "", "subnet_cidr": "10.0.0.0/24", "ip_address": "10.0.0.20", "type": "sriov", "mode": ""},
+				{"name": "eth1", "fabric": "1", "vlan": "5002", "space": "storage", "subnet_cidr": "10.0.100.0/24", "ip_address": "", "type": "", "mode": ""},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.out, getVMHostMachineNetworkInterfacesTFState(networkInterfaces, testCase.current))
+		})
+	}
+}
+
+func TestEncodeVMHostMachineNetworkInterfaces(t *testing.T) {
+	networkInterface := func(properties map[string]interface{}) map[string]interface{} {
+		n := map[string]interface{}{"name": "eth0", "fabric": "", "vlan": "", "space": "", "subnet_cidr": "", "ip_address": "", "type": "", "mode": ""}
+		for k, v := range properties {
+			n[k] = v
+		}
+		return n
+	}
+
+	testCases := []struct {
+		name              string
+		networkInterfaces []interface{}
+		out               string
+		err               string
+	}{
+		{
+			name: "valid",
+			networkInterfaces: []interface{}{
+				networkInterface(map[string]interface{}{"fabric": "fabric-0", "vlan": "100", "subnet_cidr": "10.0.0.0/24", "ip_address": "10.0.0.20"}),
+				networkInterface(map[string]interface{}{"name": "eth1", "space": "data", "type": "macvlan", "mode": "vepa"}),
+				networkInterface(map[string]interface{}{"name": "eth2", "space": "data", "type": "sriov"}),
+				networkInterface(map[string]interface{}{"name": "eth3", "subnet_cidr": "2001:db8::/64", "ip_address": "2001:db8::20"}),
+			},
+			out: "eth0:fabric=fabric-0,vlan=100,subnet_cidr=10.0.0.0/24,ip=10.0.0.20;eth1:space=data,type=macvlan,mode=vepa;eth2:space=data,type=sriov;eth3:subnet_cidr=2001:db8::/64,ip=2001:db8::20",
+		},
+		{
+			name:              "missing network constraint",
+			networkInterfaces: []interface{}{networkInterface(map[string]interface{}{"fabric": "fabric-0"})},
+			err:               "at least one of the network interface properties (vlan, subnet_cidr, ip_address, space) is required for network interface (eth0)",
+		},
+		{
+			name:              "separator in value",
+			networkInterfaces: []interface{}{networkInterface(map[string]interface{}{"space": "data,ip=10.0.0.1"})},
+			err:               `invalid space of network interface (eth0) (data,ip=10.0.0.1): it must not contain whitespace nor any of ";,="`,
+		},
+		{
+			name:              "mode without macvlan",
+			networkInterfaces: []interface{}{networkInterface(map[string]interface{}{"space": "data", "type": "bridge", "mode": "vepa"})},
+			err:               "the mode of network interface (eth0) can only be set when its type is macvlan",
+		},
+		{
+			name:              "invalid IP address",
+			networkInterfaces: []interface{}{networkInterface(map[string]interface{}{"ip_address": "10.0.0"})},
+			err:               "invalid IP address (10.0.0) for network interface (eth0)",
+		},
+		{
+			name: "duplicate name",
+			networkInterfaces: []interface{}{
+				networkInterface(map[string]interface{}{"space": "data"}),
+				networkInterface(map[string]interface{}{"space": "storage"}),
+			},
+			err: "network interface (eth0) is defined more than once",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out, err := encodeVMHostMachineNetworkInterfaces(testCase.networkInterfaces)
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.out, out)
+		})
+	}
+}
+
+func TestEncodeVMHostMachineStorageDisks(t *testing.T) {
+	testCases := []struct {
+		name         string
+		storageDisks []interface{}
+		out          string
+		err          string
+	}{
+		{
+			name: "valid",
+			storageDisks: []interface{}{
+				map[string]interface{}{"size_gigabytes": 15, "pool": "", "tags": []interface{}{}},
+				map[string]interface{}{"size_gigabytes": 20, "pool": "fast", "tags": []interface{}{}},
+				map[string]interface{}{"size_gigabytes": 30, "pool": "", "tags": []interface{}{"ssd", "nvme"}},
+			},
+			out: "disk0:15,disk1:20(fast),disk2:30(ssd,nvme)",
+		},
+		{
+			name: "separator in tag",
+			storageDisks: []interface{}{
+				map[string]interface{}{"size_gigabytes": 15, "pool": "fast", "tags": []interface{}{"ssd),disk9:1(slow"}},
+			},
+			err: `invalid pool or tag of storage disk (0) (ssd),disk9:1(slow): it must not contain whitespace nor any of ":,()"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out, err := encodeVMHostMachineStorageDisks(testCase.storageDisks)
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.out, out)
+		})
+	}
+}
+
+func TestSelectVMHostMachineHost(t *testing.T) {
+	vmHosts := []entity.VMHost{
+		{ID: 1, Name: "lxd-01", Total: entity.VMHostResource{Cores: 4, Memory: 8192, LocalStorage: 100000000000}, Used: entity.VMHostResource{Cores: 4, Memory: 0}},
+		{ID: 2, Name: "lxd-02", Total: entity.VMHostResource{Cores: 8, Memory: 16384, LocalStorage: 100000000000}, Used: entity.VMHostResource{Memory: 12288}},
+		{ID: 3, Name: "lxd-03", Total: entity.VMHostResource{Cores: 8, Memory: 16384, LocalStorage: 100000000000}, Used: entity.VMHostResource{Memory: 8192}},
+	}
+
+	testCases := []struct {
+		name     string
+		request  *vmHostMachineRequest
+		selected int
+		problems map[string][]string
+	}{
+		{
+			name:     "most available memory among the fitting members",
+			request:  &vmHostMachineRequest{Cores: 2, Memory: 2048, Storage: map[string]int64{}},
+			selected: 3,
+			problems: map[string][]string{"lxd-01": {"2 CPU cores requested, 0 available"}},
+		},
+		{
+			name:     "no fitting member",
+			request:  &vmHostMachineRequest{Cores: 2, Memory: 10240, Storage: map[string]int64{}},
+			selected: 1,
+			problems: map[string][]string{
+				"lxd-01": {"2 CPU cores requested, 0 available", "10240 MB of memory requested, 8192 MB available"},
+				"lxd-02": {"10240 MB of memory requested, 4096 MB available"},
+				"lxd-03": {"10240 MB of memory requested, 8192 MB available"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			vmHost, problems := selectVMHostMachineHost(vmHosts, testCase.request)
+			assert.Equal(t, testCase.selected, vmHost.ID)
+			assert.Equal(t, testCase.problems, problems)
+		})
+	}
+}
+
+func TestReleaseVMClusterHostMachineRequest(t *testing.T) {
+	// A full VM cluster, where the replaced machine is composed on lxd-01
+	vmHosts := []entity.VMHost{
+		{ID: 1, Name: "lxd-01", Total: entity.VMHostResource{Cores: 4, Memory: 8192, LocalStorage: 100000000000}, Used: entity.VMHostResource{Cores: 4, Memory: 8192}},
+		{ID: 2, Name: "lxd-02", Total: entity.VMHostResource{Cores: 4, Memory: 8192, LocalStorage: 100000000000}, Used: entity.VMHostResource{Cores: 4, Memory: 8192}},
+	}
+	replacedRequest := &vmHostMachineRequest{Cores: 2, Memory: 4096, Storage: map[string]int64{}}
+	request := &vmHostMachineRequest{Cores: 2, Memory: 4096, Storage: map[string]int64{}}
+
+	testCases := []struct {
+		name           string
+		replacedVMHost string
+		selected       int
+		problems       map[string][]string
+	}{
+		{
+			name:           "replaced machine member given by ID",
+			replacedVMHost: "1",
+			selected:       1,
+			problems: map[string][]string{
+				"lxd-02": {"2 CPU cores requested, 0 available", "4096 MB of memory requested, 0 MB available"},
+			},
+		},
+		{
+			name:           "replaced machine member given by name",
+			replacedVMHost: "lxd-01",
+			selected:       1,
+			problems: map[string][]string{
+				"lxd-02": {"2 CPU cores requested, 0 available", "4096 MB of memory requested, 0 MB available"},
+			},
+		},
+		{
+			name:           "unknown member",
+			replacedVMHost: "lxd-03",
+			selected:       1,
+			problems: map[string][]string{
+				"lxd-01": {"2 CPU cores requested, 0 available", "4096 MB of memory requested, 0 MB available"},
+				"lxd-02": {"2 CPU cores requested, 0 available", "4096 MB of memory requested, 0 MB available"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			released := releaseVMClusterHostMachineRequest(vmHosts, testCase.replacedVMHost, replacedRequest)
+			vmHost, problems := selectVMHostMachineHost(released, request)
+			assert.Equal(t, testCase.selected, vmHost.ID)
+			assert.Equal(t, testCase.problems, problems)
+			// The original VM hosts are left untouched
+			assert.Equal(t, 4, vmHosts[0].Used.Cores)
+		})
+	}
+}
+
+func TestVMHostMachineCapacityProblemsCleared(t *testing.T) {
+	state := map[string]string{
+		"id":                               "abc123",
+		"vm_host":                          "1",
+		"cores":                            "2",
+		"memory":                           "4096",
+		"hugepages_backed":                 "false",
+		"storage_disks.#":                  "1",
+		"storage_disks.0.size_gigabytes":   "10",
+		"storage_disks.0.pool":             "default",
+		"network_interfaces.#":             "1",
+		"network_interfaces.0.name":        "eth0",
+		"network_interfaces.0.fabric":      "fabric-0",
+		"network_interfaces.0.subnet_cidr": "10.0.0.0/24",
+		"capacity_problems.#":              "1",
+		"capacity_problems.0":              "kvm-01: 2 CPU cores requested, 1 available",
+	}
+	testCases := []struct {
+		name          string
+		capacityCheck string
+	}{
+		{
+			name:          "capacity not checked",
+			capacityCheck: "none",
+		},
+		{
+			name:          "machine not replaced",
+			capacityCheck: "warn",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := map[string]string{"capacity_check": testCase.capacityCheck}
+			for k, v := range state {
+				s[k] = v
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"vm_host":            "1",
+				"cores":              2,
+				"memory":             4096,
+				"storage_disks":      []interface{}{map[string]interface{}{"size_gigabytes": 10, "pool": "default"}},
+				"network_interfaces": []interface{}{map[string]interface{}{"name": "eth0", "fabric": "fabric-0", "subnet_cidr": "10.0.0.0/24"}},
+				"capacity_check":     testCase.capacityCheck,
+			})
+			diff, err := resourceMaasVMHostMachine().Diff(context.Background(), &terraform.InstanceState{ID: "abc123", Attributes: s}, config, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "0", diff.Attributes["capacity_problems.#"].New)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package maas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/google/go-querystring/query"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMaasVolumeGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage MAAS machines' LVM volume groups.",
+		CreateContext: resourceVolumeGroupCreate,
+		ReadContext:   resourceVolumeGroupRead,
+		UpdateContext: resourceVolumeGroupUpdate,
+		DeleteContext: resourceVolumeGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				idParts := strings.Split(d.Id(), ":")
+				if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected MACHINE:VOLUME_GROUP", d.Id())
+				}
+				client := meta.(*ClientConfig).Client
+
+				machine, err := getMachine(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				volumeGroup, err := getVolumeGroup(client, machine.SystemID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				tfState := map[string]interface{}{
+					"id":      fmt.Sprintf("%v", volumeGroup.ID),
+					"machine": machine.SystemID,
+				}
+				if err := setTerraformState(d, tfState); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"available_size_gigabytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the volume group not used by logical volumes (given in GB).",
+			},
+			"block_devices": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"block_devices", "partitions"},
+				Description:  "A set of block devices (ID, name, ID path or path) used as physical volumes of the volume group. This is computed if it's not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"machine": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The machine identifier (system ID, hostname, or FQDN) that owns the volume group.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The volume group name.",
+			},
+			"partitions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "A set of partitions (ID, name or path) used as physical volumes of the volume group. This is computed if it's not set.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"size_gigabytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the volume group (given in GB).",
+			},
+			"used_size_gigabytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the volume group used by logical volumes (given in GB).",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The volume group UUID.",
+			},
+		},
+	}
+}
+
+func resourceVolumeGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	members, err := getStorageMemberIDs(client, machine.SystemID, d, []string{"block_devices"}, []string{"partitions"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	params := entity.VolumeGroupCreateParams{
+		Name:         d.Get("name").(string),
+		BlockDevices: members["block_devices"],
+		Partitions:   members["partitions"],
+	}
+	volumeGroup, err := client.VolumeGroups.Create(machine.SystemID, &params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%v", volumeGroup.ID))
+
+	return resourceVolumeGroupRead(ctx, d, meta)
+}
+
+func resourceVolumeGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroup, err := getVolumeGroup(client, machine.SystemID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	devices, err := getVolumeGroupDevices(volumeGroup)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	blockDevices, partitions := splitStorageMembers(devices)
+	tfState := map[string]interface{}{
+		"name":                     volumeGroup.Name,
+		"uuid":                     volumeGroup.UUID,
+		"size_gigabytes":           int(volumeGroup.Size / (1024 * 1024 * 1024)),
+		"used_size_gigabytes":      int(volumeGroup.UsedSize / (1024 * 1024 * 1024)),
+		"available_size_gigabytes": int(volumeGroup.AvailableSize / (1024 * 1024 * 1024)),
+		"block_devices":            getStorageMembersTFState(blockDevices, convertToStringSlice(d.Get("block_devices").(*schema.Set).List())),
+		"partitions":               getStorageMembersTFState(partitions, convertToStringSlice(d.Get("partitions").(*schema.Set).List())),
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVolumeGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroup, err := getVolumeGroup(client, machine.SystemID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	devices, err := getVolumeGroupDevices(volumeGroup)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	members, err := getStorageMemberIDs(client, machine.SystemID, d, []string{"block_devices"}, []string{"partitions"})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	blockDevices, partitions := splitStorageMembers(devices)
+	params := entity.VolumeGroupUpdateParams{Name: d.Get("name").(string)}
+	params.AddBlockDevices, params.RemoveBlockDevices = getStorageMemberChanges(blockDevices, members["block_devices"])
+	params.AddPartitions, params.RemovePartitions = getStorageMemberChanges(partitions, members["partitions"])
+	if err := updateVolumeGroup(client, machine.SystemID, id, &params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceVolumeGroupRead(ctx, d, meta)
+}
+
+func resourceVolumeGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	machine, err := getMachine(client, d.Get("machine").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroupClient, err := getVolumeGroupAPIClient(client, machine.SystemID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := volumeGroupClient.Delete(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getVolumeGroup(client *client.Client, machineID string, identifier string) (*entity.VolumeGroup, error) {
+	volumeGroups, err := client.VolumeGroups.Get(machineID)
+	if err != nil {
+		return nil, err
+	}
+	for _, volumeGroup := range volumeGroups {
+		if fmt.Sprintf("%v", volumeGroup.ID) == identifier || volumeGroup.Name == identifier {
+			return &volumeGroup, nil
+		}
+	}
+	return nil, fmt.Errorf("volume group (%s) was not found on machine (%s)", identifier, machineID)
+}
+
+// getVolumeGroupDevices returns the block devices and partitions used as physical volumes of the volume group.
+func getVolumeGroupDevices(volumeGroup *entity.VolumeGroup) ([]entity.RAIDDevice, error) {
+	devices := []entity.RAIDDevice{}
+	if volumeGroup.Devices == nil {
+		return devices, nil
+	}
+	data, err := json.Marshal(volumeGroup.Devices)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &devices); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// getVolumeGroupAPIClient returns the API client of a volume group. The volume group endpoint of
+// the MAAS API is nodes/{system_id}/volume-group/{id}, and not the nodes/{system_id}/volume-groups/{id}
+// one used by client.VolumeGroup, so the volume groups are updated through the API client.
+func getVolumeGroupAPIClient(client *client.Client, machineID string, id string) (*client.APIClient, error) {
+	apiClient, err := getAPIClient(client)
+	if err != nil {
+		return nil, err
+	}
+	volumeGroupClient := apiClient.GetSubObject("nodes").GetSubObject(machineID).GetSubObject("volume-group").GetSubObject(id)
+	return &volumeGroupClient, nil
+}
+
+func updateVolumeGroup(client *client.Client, machineID string, id int, params *entity.VolumeGroupUpdateParams) error {
+	qsp, err := query.Values(params)
+	if err != nil {
+		return err
+	}
+	volumeGroupClient, err := getVolumeGroupAPIClient(client, machineID, fmt.Sprintf("%v", id))
+	if err != nil {
+		return err
+	}
+	return volumeGroupClient.Put(qsp, func(data []byte) error { return nil })
+}
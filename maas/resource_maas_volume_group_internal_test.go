@@ -0,0 +1,40 @@
+package maas
+
+import (
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVolumeGroupDevices(t *testing.T) {
+	volumeGroup := &entity.VolumeGroup{
+		Devices: []interface{}{
+			map[string]interface{}{"id": float64(5), "name": "sdb", "path": "/dev/disk/by-dname/sdb", "type": "physical"},
+			map[string]interface{}{"id": float64(12), "path": "/dev/disk/by-dname/sda-part3", "type": "partition"},
+		},
+	}
+
+	devices, err := getVolumeGroupDevices(volumeGroup)
+	assert.NoError(t, err)
+	blockDevices, partitions := splitStorageMembers(devices)
+	assert.Equal(t, []entity.RAIDDevice{{ID: 5, Name: "sdb", Path: "/dev/disk/by-dname/sdb", Type: "physical"}}, blockDevices)
+	assert.Equal(t, []entity.RAIDDevice{{ID: 12, Path: "/dev/disk/by-dname/sda-part3", Type: "partition"}}, partitions)
+
+	devices, err = getVolumeGroupDevices(&entity.VolumeGroup{})
+	assert.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func TestFindLogicalVolumeGroup(t *testing.T) {
+	volumeGroups := []entity.VolumeGroup{
+		{ID: 1, Name: "vg0", LogicalVolumes: []entity.VirtualBlockDevice{{BlockDevice: entity.BlockDevice{ID: 10}}}},
+		{ID: 2, Name: "vg1", LogicalVolumes: []entity.VirtualBlockDevice{{BlockDevice: entity.BlockDevice{ID: 20}}, {BlockDevice: entity.BlockDevice{ID: 21}}}},
+	}
+
+	volumeGroup := findLogicalVolumeGroup(volumeGroups, 21)
+	if assert.NotNil(t, volumeGroup) {
+		assert.Equal(t, "vg1", volumeGroup.Name)
+	}
+	assert.Nil(t, findLogicalVolumeGroup(volumeGroups, 5))
+}
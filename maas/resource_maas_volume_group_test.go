@@ -0,0 +1,157 @@
+package maas_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"terraform-provider-maas/maas"
+	"terraform-provider-maas/maas/testutils"
+	"testing"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccMaasVolumeGroup(machine string, name string) string {
+	return fmt.Sprintf(`
+data "maas_machine" "machine" {
+  hostname = "%s"
+}
+
+resource "maas_block_device" "vdb" {
+  machine        = data.maas_machine.machine.id
+  name           = "vdb"
+  size_gigabytes = 20
+  id_path        = "/dev/vdb"
+}
+
+resource "maas_volume_group" "test" {
+  machine       = data.maas_machine.machine.id
+  name          = "%s"
+  block_devices = [maas_block_device.vdb.name]
+}
+`, machine, name)
+}
+
+func TestAccResourceMaasVolumeGroup_basic(t *testing.T) {
+
+	var volumeGroup entity.VolumeGroup
+	machine := os.Getenv("TF_ACC_BLOCK_DEVICE_MACHINE")
+
+	checks := []resource.TestCheckFunc{
+		testAccMaasVolumeGroupCheckExists("maas_volume_group.test", &volumeGroup),
+		resource.TestCheckResourceAttr("maas_volume_group.test", "block_devices.#", "1"),
+		resource.TestCheckResourceAttr("maas_volume_group.test", "block_devices.0", "vdb"),
+		resource.TestCheckResourceAttr("maas_volume_group.test", "partitions.#", "0"),
+		resource.TestCheckResourceAttr("maas_volume_group.test", "used_size_gigabytes", "0"),
+		resource.TestCheckResourceAttrSet("maas_volume_group.test", "size_gigabytes"),
+		resource.TestCheckResourceAttrSet("maas_volume_group.test", "uuid"),
+		resource.TestCheckResourceAttrPair("maas_volume_group.test", "machine", "data.maas_machine.machine", "id"),
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, []string{"TF_ACC_BLOCK_DEVICE_MACHINE"}) },
+		Providers:    testutils.TestAccProviders,
+		CheckDestroy: testAccCheckMaasVolumeGroupDestroy,
+		ErrorCheck:   func(err error) error { return err },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaasVolumeGroup(machine, "tf-vg-01"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_volume_group.test", "name", "tf-vg-01"))...),
+			},
+			// Test update
+			{
+				Config: testAccMaasVolumeGroup(machine, "tf-vg-02"),
+				Check: resource.ComposeTestCheckFunc(
+					append(checks, resource.TestCheckResourceAttr("maas_volume_group.test", "name", "tf-vg-02"))...),
+			},
+			// Test import
+			{
+				ResourceName:      "maas_volume_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["maas_volume_group.test"]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", "maas_volume_group.test")
+					}
+
+					if rs.Primary.ID == "" {
+						return "", fmt.Errorf("resource id not set")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["machine"], rs.Primary.Attributes["id"]), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccMaasVolumeGroupCheckExists(rn string, volumeGroup *entity.VolumeGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		gotVolumeGroup, err := testAccGetMaasVolumeGroup(rs.Primary.Attributes["machine"], rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting volume group: %s", err)
+		}
+		if gotVolumeGroup == nil {
+			return fmt.Errorf("MAAS Volume group (%s) was not found", rs.Primary.ID)
+		}
+
+		*volumeGroup = *gotVolumeGroup
+
+		return nil
+	}
+}
+
+// testAccGetMaasVolumeGroup returns the volume group of a machine with the given ID, or nil if it doesn't exist.
+func testAccGetMaasVolumeGroup(machine string, id string) (*entity.VolumeGroup, error) {
+	conn := testutils.TestAccProvider.Meta().(*maas.ClientConfig).Client
+	volumeGroups, err := conn.VolumeGroups.Get(machine)
+	if err != nil {
+		return nil, err
+	}
+	for _, volumeGroup := range volumeGroups {
+		if fmt.Sprintf("%v", volumeGroup.ID) == id {
+			return &volumeGroup, nil
+		}
+	}
+	return nil, nil
+}
+
+func testAccCheckMaasVolumeGroupDestroy(s *terraform.State) error {
+	// loop through the resources in state, verifying each maas_volume_group
+	// is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "maas_volume_group" {
+			continue
+		}
+
+		// Retrieve our maas_volume_group by referencing it's state ID for API lookup
+		response, err := testAccGetMaasVolumeGroup(rs.Primary.Attributes["machine"], rs.Primary.ID)
+		if err == nil {
+			if response != nil {
+				return fmt.Errorf("MAAS Volume group (%s) still exists.", rs.Primary.ID)
+			}
+
+			return nil
+		}
+
+		// If the error is equivalent to 404 not found, the maas_volume_group is destroyed.
+		// Otherwise return the error
+		if !strings.Contains(err.Error(), "404 Not Found") {
+			return err
+		}
+	}
+
+	return nil
+}
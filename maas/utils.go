@@ -91,6 +91,35 @@ func isEmailAddress(i interface{}, p cty.Path) diag.Diagnostics {
 	return diags
 }
 
+// getAPIClient returns the MAAS API client wrapped by gomaasclient.
+// It is only meant for the MAAS API operations not exposed by gomaasclient yet.
+func getAPIClient(c *client.Client) (*client.APIClient, error) {
+	m, ok := c.Machine.(*client.Machine)
+	if !ok {
+		return nil, fmt.Errorf("unexpected MAAS client implementation (%T)", c.Machine)
+	}
+	return &m.APIClient, nil
+}
+
+// getWriteOnlyString returns the value of a write-only string argument. Write-only
+// arguments are never persisted in the Terraform state, so they can only be read
+// from the raw configuration during the apply.
+func getWriteOnlyString(d *schema.ResourceData, key string) (string, error) {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return "", fmt.Errorf("failed to read write-only argument %q: %s", key, diags[0].Summary)
+	}
+	if !value.Type().Equals(cty.String) || value.IsNull() || !value.IsKnown() {
+		return "", nil
+	}
+	return value.AsString(), nil
+}
+
+// getListID returns a stable ID for the data sources returning a list of objects.
+func getListID(ids []string) string {
+	return fmt.Sprintf("%d", schema.HashString(strings.Join(ids, ",")))
+}
+
 func getNetworkInterface(client *client.Client, machineSystemID string, identifier string) (*entity.NetworkInterface, error) {
 	networkInterfaces, err := client.NetworkInterfaces.Get(machineSystemID)
 	if err != nil {
@@ -1,10 +1,15 @@
 package maas
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,3 +71,28 @@ func TestConvertToStringSlice(t *testing.T) {
 		})
 	}
 }
+
+// testApplyResourceData returns the resource data given to the Create function of the resource
+// (or the Update one if state is set) when the diff is applied. Unlike schema.TestResourceDataRaw,
+// this sets the raw configuration, so that the write-only arguments can be read.
+func testApplyResourceData(t *testing.T, r *schema.Resource, state map[string]string, attributes map[string]*terraform.ResourceAttrDiff, rawConfig map[string]cty.Value) *schema.ResourceData {
+	t.Helper()
+
+	var result *schema.ResourceData
+	capture := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		result = d
+		return nil
+	}
+	r.CreateContext = capture
+	r.UpdateContext = capture
+
+	var s *terraform.InstanceState
+	if state != nil {
+		s = &terraform.InstanceState{ID: "1", Attributes: state}
+	}
+	diff := &terraform.InstanceDiff{Attributes: attributes, RawConfig: cty.ObjectVal(rawConfig)}
+	if _, diags := r.Apply(context.Background(), s, diff, nil); diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	return result
+}